@@ -0,0 +1,95 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cumulusware/gossamer/internal/generator"
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add FEATURE...",
+	Short: "Add a feature to an existing gossamer project",
+	Long: fmt.Sprintf(`Add a feature that was not selected when the project was initialized.
+
+Run from the project root. The files belonging to the feature are created,
+and the shared files that change with it, such as the router, the app
+wiring, and the base layout, are updated using the same three-way merge as
+gossamer upgrade, so your own edits to them are kept. The feature is then
+recorded in .gossamer.yaml.
+
+Example:
+  gossamer add api
+
+Available features: %s`, strings.Join(generator.FeatureNames(), ", ")),
+	Args:      cobra.MinimumNArgs(1),
+	ValidArgs: generator.FeatureNames(),
+	Run:       runAdd,
+}
+
+var (
+	flagAddDir string
+	flagAddDry bool
+)
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+
+	addCmd.Flags().StringVarP(&flagAddDir, "dir", "d", ".", "Root directory of the gossamer project")
+	addCmd.Flags().BoolVar(&flagAddDry, "dry-run", false, "Show what would change without changing any files")
+	addTemplatesFlag(addCmd)
+}
+
+func runAdd(cmd *cobra.Command, args []string) {
+	upgrader, err := generator.NewUpgrader(flagAddDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+		os.Exit(1)
+	}
+	useTemplates(upgrader)
+
+	for _, feature := range args {
+		if err := upgrader.AddFeature(feature); err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+			os.Exit(1)
+		}
+	}
+
+	if flagAddDry {
+		fmt.Println(color.YellowString("🔍 Dry run mode - showing what would be changed:"))
+		results, err := upgrader.Plan()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		printUpgradeResults(results)
+		return
+	}
+
+	fmt.Println(color.CyanString("🚀 Adding %s...", strings.Join(args, ", ")))
+
+	results, err := upgrader.Apply()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error adding feature: %v\n"), err)
+		os.Exit(1)
+	}
+	if printUpgradeAttention(printUpgradeResults(results)) {
+		return
+	}
+
+	fmt.Println(color.GreenString("\n✅ Added %s successfully!", strings.Join(args, ", ")))
+	if slices.Contains(args, "api") && len(upgrader.Manifest().Resources) > 0 {
+		fmt.Println(color.YellowString("\nResources generated before the API was added have no API handlers;"))
+		fmt.Println(color.YellowString("write them following internal/adapters/handlers/api/user_handler.go if you need them."))
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cumulusware/gossamer/internal/generator"
+)
+
+var blueprintCmd = &cobra.Command{
+	Use:   "blueprint",
+	Short: "Manage the blueprints projects can be generated from",
+	Long: `Manage the blueprints projects can be generated from.
+
+A blueprint is a directory, or a .tar.gz or .zip archive of one, containing
+a blueprint.yaml and a templates directory. The blueprint.yaml names the
+blueprint, lists the files to generate (source template, destination,
+permissions, and conditional), and defines extra options to ask for:
+
+  name: admin
+  description: Internal admin tool
+  extends: default            # start from the files of the built-in blueprint
+  exclude:
+    - internal/infrastructure/web/templates/register.gohtml
+  files:
+    - source: admin/audit.gotmpl
+      destination: internal/audit/audit.go
+      conditional: Audit
+  options:
+    - name: Audit
+      title: Record an audit log?
+      type: bool              # string (default), bool, or choice
+
+Templates in the templates directory shadow the built-in templates with the
+same path, so a blueprint only contains the templates it adds or changes.
+Option values are available in templates as {{.Options.Audit}}.
+
+Generate a project from an installed blueprint with
+gossamer init --blueprint NAME.`,
+}
+
+var blueprintListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available blueprints",
+	Args:  cobra.NoArgs,
+	Run:   runBlueprintList,
+}
+
+var blueprintInstallCmd = &cobra.Command{
+	Use:   "install PATH",
+	Short: "Install a blueprint from a directory or archive",
+	Args:  cobra.ExactArgs(1),
+	Run:   runBlueprintInstall,
+}
+
+var blueprintRemoveCmd = &cobra.Command{
+	Use:   "remove NAME",
+	Short: "Remove an installed blueprint",
+	Args:  cobra.ExactArgs(1),
+	Run:   runBlueprintRemove,
+}
+
+var flagBlueprintForce bool
+
+func init() {
+	rootCmd.AddCommand(blueprintCmd)
+	blueprintCmd.AddCommand(blueprintListCmd, blueprintInstallCmd, blueprintRemoveCmd)
+
+	blueprintInstallCmd.Flags().BoolVarP(&flagBlueprintForce, "force", "f", false, "Replace an installed blueprint with the same name")
+}
+
+func runBlueprintList(cmd *cobra.Command, args []string) {
+	blueprints, err := generator.ListBlueprints()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+		os.Exit(1)
+	}
+
+	for _, b := range blueprints {
+		location := "built-in"
+		if b.Dir != "" {
+			location = b.Dir
+		}
+		fmt.Printf("  🧩 %s %s\n", color.CyanString(b.Name), color.HiBlackString("(%s)", location))
+		if b.Description != "" {
+			fmt.Printf("     %s\n", b.Description)
+		}
+		for _, o := range b.Options {
+			fmt.Printf("     - option %s: %s\n", o.Name, o.Title)
+		}
+	}
+}
+
+func runBlueprintInstall(cmd *cobra.Command, args []string) {
+	b, err := generator.InstallBlueprint(args[0], flagBlueprintForce)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+		os.Exit(1)
+	}
+
+	fmt.Printf(color.GreenString("✅ Installed blueprint '%s' in %s\n"), b.Name, b.Dir)
+	fmt.Printf("\nCreate a project with: gossamer init --blueprint %s\n", b.Name)
+}
+
+func runBlueprintRemove(cmd *cobra.Command, args []string) {
+	if err := generator.RemoveBlueprint(args[0]); err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+		os.Exit(1)
+	}
+
+	fmt.Printf(color.GreenString("✅ Removed blueprint '%s'\n"), args[0])
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cumulusware/gossamer/internal/generator"
+)
+
+var generateCmd = &cobra.Command{
+	Use:     "generate",
+	Aliases: []string{"g"},
+	Short:   "Generate code inside an existing gossamer project",
+}
+
+var resourceCmd = &cobra.Command{
+	Use:   "resource NAME [field:type]...",
+	Short: "Generate a CRUD resource",
+	Long: fmt.Sprintf(`Generate a complete CRUD vertical slice inside an existing gossamer project.
+
+Run from the project root. This creates the domain entity, repository
+interface and service, a repository adapter and goose migration for the
+project's database, web handlers with list/show/edit templates and, when
+the project includes the REST API, /api/v1 JSON handlers. The routes are
+registered in internal/infrastructure/web/router.go and the service is
+wired up in internal/app/app.go.
+
+Example:
+  gossamer generate resource Product name:string price:decimal published:bool
+
+Supported field types: %s`, strings.Join(fieldTypeNames(), ", ")),
+	Args: cobra.MinimumNArgs(1),
+	Run:  runGenerateResource,
+}
+
+var (
+	flagResourceForce bool
+	flagResourceDry   bool
+	flagResourceDir   string
+)
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+	generateCmd.AddCommand(resourceCmd)
+
+	resourceCmd.Flags().BoolVarP(&flagResourceForce, "force", "f", false, "Overwrite existing resource files")
+	resourceCmd.Flags().BoolVar(&flagResourceDry, "dry-run", false, "Show what would be created without changing any files")
+	resourceCmd.Flags().StringVarP(&flagResourceDir, "dir", "d", ".", "Root directory of the gossamer project")
+	addTemplatesFlag(resourceCmd)
+}
+
+func runGenerateResource(cmd *cobra.Command, args []string) {
+	res, err := generator.ParseResource(args[0], args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+		os.Exit(1)
+	}
+
+	gen, err := generator.NewResourceGenerator(flagResourceDir, res)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+		os.Exit(1)
+	}
+	useTemplates(gen)
+
+	if flagResourceDry {
+		created, modified, err := gen.GetFileList()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		overlayFiles, err := gen.OverlayFiles()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		fmt.Println(color.YellowString("🔍 Dry run mode - showing what would be changed:"))
+		for _, file := range created {
+			fmt.Printf("  📄 %s%s\n", file, overlayNote(slices.Contains(overlayFiles, file)))
+		}
+		for _, file := range modified {
+			fmt.Printf("  ✏️  %s\n", file)
+		}
+		return
+	}
+
+	fmt.Println(color.CyanString("🚀 Generating resource %s...", res.Name))
+
+	if err := gen.Generate(flagResourceForce); err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error generating resource: %v\n"), err)
+		os.Exit(1)
+	}
+
+	fmt.Printf(color.GreenString("\n✅ Resource '%s' generated successfully!\n"), res.Name)
+	fmt.Println(color.YellowString("\n🚀 Next steps:"))
+	fmt.Println("  1. Review the generated migration")
+	fmt.Println("  2. Restart the app to apply the migration")
+	fmt.Printf("  3. Visit /%s\n", res.URLPath)
+}
+
+func fieldTypeNames() []string {
+	names := make([]string, 0, len(generator.FieldTypes))
+	for name := range generator.FieldTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -6,9 +6,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -27,18 +30,39 @@ This command will create a new directory with the project name and scaffold
 a complete Go web application with the following features:
 - Clean architecture with domain-driven design
 - User authentication and authorization
-- Database integration with PostgreSQL
+- Database integration with PostgreSQL, MySQL/MariaDB, or SQLite
 - Optional HTMX for dynamic interactions
 - Optional REST API endpoints
 - Development tooling (Air, Justfile, Docker Compose)
-- Security best practices (CSRF, password hashing, sessions)`,
+- Multi-stage Dockerfile and production compose file
+- Security best practices (CSRF, password hashing, sessions)
+
+Every configuration value can be supplied with flags or a YAML config file
+(--config). Use --yes (or --no-input) to skip all prompts, e.g. from CI:
+
+  gossamer init my-app --module github.com/acme/my-app --api --yes
+
+Use --blueprint to generate a different shape of project from an installed
+blueprint (see gossamer blueprint list).`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runInit,
 }
 
 var (
-	flagForce bool
-	flagDry   bool
+	flagForce       bool
+	flagDry         bool
+	flagNoInput     bool
+	flagConfigFile  string
+	flagModulePath  string
+	flagDescription string
+	flagAuthor      string
+	flagHTMX        bool
+	flagAPI         bool
+	flagRBAC        bool
+	flagJobs        bool
+	flagTracing     bool
+	flagDatabase    string
+	flagBlueprint   string
 )
 
 func init() {
@@ -46,17 +70,24 @@ func init() {
 
 	initCmd.Flags().BoolVarP(&flagForce, "force", "f", false, "Force creation even if directory exists")
 	initCmd.Flags().BoolVar(&flagDry, "dry-run", false, "Show what would be created without actually creating files")
+	initCmd.Flags().BoolVarP(&flagNoInput, "yes", "y", false, "Skip all prompts and use flags, config file, and defaults")
+	initCmd.Flags().BoolVar(&flagNoInput, "no-input", false, "Alias for --yes")
+	initCmd.Flags().StringVarP(&flagConfigFile, "config", "c", "", "Read project configuration from a YAML file")
+	initCmd.Flags().StringVar(&flagModulePath, "module", "", "Go module path (e.g., github.com/username/project-name)")
+	initCmd.Flags().StringVar(&flagDescription, "description", "", "Brief description of the project")
+	initCmd.Flags().StringVar(&flagAuthor, "author", "", "Author name")
+	initCmd.Flags().BoolVar(&flagHTMX, "htmx", false, "Include HTMX for dynamic interactions")
+	initCmd.Flags().BoolVar(&flagAPI, "api", false, "Include REST API endpoints")
+	initCmd.Flags().BoolVar(&flagRBAC, "rbac", false, "Include role-based access control")
+	initCmd.Flags().BoolVar(&flagJobs, "jobs", false, "Include background jobs")
+	initCmd.Flags().BoolVar(&flagTracing, "tracing", false, "Include OpenTelemetry tracing")
+	initCmd.Flags().StringVar(&flagDatabase, "database", "", "Database type ("+strings.Join(config.SupportedDatabases, ", ")+")")
+	initCmd.Flags().StringVarP(&flagBlueprint, "blueprint", "b", "", "Blueprint to generate the project from (default \""+generator.DefaultBlueprint+"\")")
+	addTemplatesFlag(initCmd)
 }
 
 func runInit(cmd *cobra.Command, args []string) {
-	var projectName string
-
-	if len(args) > 0 {
-		projectName = args[0]
-	}
-
-	// Get project configuration through interactive prompts
-	projectConfig, err := prompts.GetProjectConfig(projectName)
+	projectConfig, blueprint, err := resolveProjectConfig(cmd, args)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, color.RedString("Error getting project configuration: %v\n"), err)
 		os.Exit(1)
@@ -73,12 +104,15 @@ func runInit(cmd *cobra.Command, args []string) {
 
 	// Generate project
 	gen := generator.New(projectConfig)
+	gen.SetBlueprint(blueprint)
+	useTemplates(gen)
 
 	if flagDry {
 		fmt.Println(color.YellowString("🔍 Dry run mode - showing what would be created:"))
 		files := gen.GetFileList()
+		overlayFiles := gen.OverlayFiles()
 		for _, file := range files {
-			fmt.Printf("  📄 %s\n", file)
+			fmt.Printf("  📄 %s%s\n", file, overlayNote(slices.Contains(overlayFiles, file)))
 		}
 		fmt.Printf("\n📊 Total files: %d\n", len(files))
 		return
@@ -92,9 +126,107 @@ func runInit(cmd *cobra.Command, args []string) {
 	}
 
 	// Print success message and next steps
+	if projectConfig.Blueprint != "" {
+		printBlueprintSuccessMessage(projectConfig)
+		return
+	}
 	printSuccessMessage(projectConfig)
 }
 
+// resolveProjectConfig builds the project configuration by layering the
+// config file, if any, under the command line flags, and returns it with the
+// blueprint it selects. Unless prompts are disabled, the result is used as
+// the defaults for the interactive forms.
+func resolveProjectConfig(cmd *cobra.Command, args []string) (*config.ProjectConfig, *generator.Blueprint, error) {
+	cfg := config.NewProjectConfig()
+	seed := &cfg
+
+	if flagConfigFile != "" {
+		fileConfig, err := config.LoadFile(flagConfigFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		seed = fileConfig
+	}
+
+	if len(args) > 0 {
+		seed.Name = args[0]
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("module") {
+		seed.ModulePath = flagModulePath
+	}
+	if flags.Changed("description") {
+		seed.Description = flagDescription
+	}
+	if flags.Changed("author") {
+		seed.Author = flagAuthor
+	}
+	if flags.Changed("htmx") {
+		seed.IncludeHTMX = flagHTMX
+	}
+	if flags.Changed("api") {
+		seed.IncludeAPI = flagAPI
+	}
+	if flags.Changed("rbac") {
+		seed.IncludeRBAC = flagRBAC
+	}
+	if flags.Changed("jobs") {
+		seed.IncludeJobs = flagJobs
+	}
+	if flags.Changed("tracing") {
+		seed.IncludeTracing = flagTracing
+	}
+	if flags.Changed("database") {
+		seed.DatabaseType = flagDatabase
+	}
+	if flags.Changed("blueprint") {
+		seed.Blueprint = flagBlueprint
+	}
+
+	blueprint, err := generator.FindBlueprint(seed.Blueprint)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The built-in blueprint is recorded as no blueprint at all
+	if seed.Blueprint == generator.DefaultBlueprint {
+		seed.Blueprint = ""
+	}
+
+	if !flagNoInput {
+		cfg, err := prompts.GetProjectConfig(seed, blueprint.Options)
+		return cfg, blueprint, err
+	}
+
+	seed.ApplyDefaults()
+	seed.ApplyOptionDefaults(blueprint.Options)
+	if err := errors.Join(seed.Validate(), seed.ValidateOptions(blueprint.Options)); err != nil {
+		return nil, nil, fmt.Errorf("invalid project configuration:\n%w", err)
+	}
+
+	return seed, blueprint, nil
+}
+
+// printBlueprintSuccessMessage prints the outcome of generating a project
+// from an installed blueprint, whose shape gossamer knows nothing about.
+func printBlueprintSuccessMessage(config *config.ProjectConfig) {
+	fmt.Printf(color.GreenString("\n✅ Project '%s' created successfully from blueprint '%s'!\n"), config.Name, config.Blueprint)
+
+	fmt.Println(color.YellowString("\n🚀 Next steps:"))
+	fmt.Printf("  1. cd %s\n", config.Name)
+	fmt.Println("  2. Follow the README.md of the project")
+
+	fmt.Println(color.GreenString("\n🎉 Happy coding!"))
+}
+
+// databaseNames are the display names of the supported databases.
+var databaseNames = map[string]string{
+	"postgresql": "PostgreSQL",
+	"mysql":      "MySQL/MariaDB",
+	"sqlite":     "SQLite",
+}
+
 func printSuccessMessage(config *config.ProjectConfig) {
 	fmt.Printf(color.GreenString("\n✅ Project '%s' created successfully!\n"), config.Name)
 
@@ -111,7 +243,7 @@ func printSuccessMessage(config *config.ProjectConfig) {
 
 	fmt.Println(color.MagentaString("\n🌐 Features included:"))
 	fmt.Println("  ✅ User authentication and authorization")
-	fmt.Println("  ✅ PostgreSQL database with migrations")
+	fmt.Printf("  ✅ %s database with migrations\n", databaseNames[config.DatabaseType])
 	fmt.Println("  ✅ Clean architecture with domain-driven design")
 	if config.IncludeHTMX {
 		fmt.Println("  ✅ HTMX for dynamic interactions")
@@ -119,8 +251,18 @@ func printSuccessMessage(config *config.ProjectConfig) {
 	if config.IncludeAPI {
 		fmt.Println("  ✅ REST API endpoints")
 	}
+	if config.IncludeRBAC {
+		fmt.Println("  ✅ Role-based access control")
+	}
+	if config.IncludeJobs {
+		fmt.Println("  ✅ Background jobs")
+	}
+	if config.IncludeTracing {
+		fmt.Println("  ✅ OpenTelemetry tracing")
+	}
 	fmt.Printf("  ✅ Security best practices (CSRF, sessions, password hashing)\n")
 	fmt.Printf("  ✅ Development tooling (Air, Justfile, Docker Compose)\n")
+	fmt.Printf("  ✅ Dockerfile and production compose file\n")
 	fmt.Printf("  ✅ Tailwind CSS for styling\n")
 	fmt.Printf("  ✅ Example tests\n")
 
@@ -128,7 +270,6 @@ func printSuccessMessage(config *config.ProjectConfig) {
 	fmt.Println("  just                     # Show all available tasks")
 	fmt.Println("  just dev                 # Start development server")
 	fmt.Println("  just test                # Run tests")
-	fmt.Println("  just db-migrate          # Run database migrations")
 
 	fmt.Println(color.YellowString("\n🚀 Next steps:"))
 	fmt.Printf("  1. cd %s\n", config.Name)
@@ -136,9 +277,12 @@ func printSuccessMessage(config *config.ProjectConfig) {
 	fmt.Println("  3. git init")
 	fmt.Println("  4. cp config/env.template config/.env")
 	fmt.Println("  5. Edit config/.env with your settings")
-	fmt.Println("  6. just db-up")
-	fmt.Println("  7. just db-migrate")
-	fmt.Println("  8. just dev")
+	if config.DatabaseType == "sqlite" {
+		fmt.Println("  6. just dev")
+	} else {
+		fmt.Println("  6. just db-up")
+		fmt.Println("  7. just dev")
+	}
 
 	fmt.Println(color.GreenString("\n🎉 Happy coding!"))
 	fmt.Println(color.GreenString("\n✨ Notice how both Gossamer and your generated project use Justfile for consistency!"))
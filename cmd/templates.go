@@ -0,0 +1,106 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cumulusware/gossamer/internal/generator"
+	"github.com/cumulusware/gossamer/internal/manifest"
+)
+
+var flagTemplates string
+
+// addTemplatesFlag adds the --templates flag, shared by every command that
+// renders templates, to cmd.
+func addTemplatesFlag(cmd *cobra.Command) {
+	usage := "Directory of templates that replace the built-in ones with the same path"
+	if dir, err := generator.UserTemplatesDir(); err == nil {
+		usage += " (default " + dir + " if it exists)"
+	}
+	cmd.Flags().StringVar(&flagTemplates, "templates", "", usage)
+}
+
+// templatesUser is implemented by the generators that render templates.
+type templatesUser interface {
+	TemplatesFS() fs.FS
+	SetTemplatesFS(fs.FS)
+}
+
+// projectUpdater is implemented by the generators that update an existing
+// project, whose manifest records the templates overlay it was generated
+// with.
+type projectUpdater interface {
+	Manifest() *manifest.Manifest
+}
+
+// useTemplates layers the templates directory given with --templates over
+// the templates used by gen. Without the flag, an existing project keeps
+// the templates directory recorded in its manifest, and a new project gets
+// the user's default templates directory if it exists.
+func useTemplates(gen templatesUser) {
+	var recorded string
+	if p, ok := gen.(projectUpdater); ok {
+		recorded = p.Manifest().Templates
+	}
+
+	dir := flagTemplates
+	switch {
+	case dir != "":
+	case recorded != "":
+		// Rendering the built-in templates instead would revert the
+		// project's customizations
+		if _, err := os.Stat(recorded); err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("Error: the project was generated with the templates in %s, which cannot be read: %v\nPass --templates with their new location.\n"), recorded, err)
+			os.Exit(1)
+		}
+		dir = recorded
+	default:
+		userDir, err := generator.UserTemplatesDir()
+		if err != nil {
+			return
+		}
+		if _, err := os.Stat(userDir); err != nil {
+			return
+		}
+		dir = userDir
+	}
+
+	overlay, err := generator.NewOverlayFS(dir, gen.TemplatesFS())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+		os.Exit(1)
+	}
+	unused, err := overlay.Unused()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+		os.Exit(1)
+	}
+
+	fmt.Println(color.MagentaString("🎨 Using templates from %s", overlay.Dir))
+	if recorded != "" && overlay.Dir != recorded {
+		fmt.Print(color.YellowString("  ⚠️  the project was generated with the templates in %s; files customized there but not here go back to the built-in templates\n", recorded))
+	}
+	for _, path := range unused {
+		fmt.Print(color.YellowString("  ⚠️  %s does not replace any built-in template\n", path))
+	}
+
+	gen.SetTemplatesFS(overlay)
+}
+
+// overlayNote returns the note printed after a file generated from the
+// templates overlay.
+func overlayNote(fromOverlay bool) string {
+	if fromOverlay {
+		return " " + color.MagentaString("(from templates overlay)")
+	}
+	return ""
+}
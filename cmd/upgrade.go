@@ -0,0 +1,139 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cumulusware/gossamer/internal/generator"
+	"github.com/cumulusware/gossamer/internal/version"
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade a generated project to the current templates",
+	Long: `Upgrade a gossamer project to the templates of this gossamer version.
+
+Run from the project root. The configuration recorded in .gossamer.yaml is
+used to render the current templates, which are then merged into the
+project:
+
+  - files you have not modified are replaced with the new version
+  - files you have modified are three-way merged using the copy originally
+    generated (stored under .gossamer/pristine) as the common ancestor;
+    overlapping changes are left as Git style conflict markers
+  - files without an original copy are left alone and the new version is
+    written next to them with a .rej suffix
+  - generated files you have deleted stay deleted
+
+Commit your work before upgrading so the changes are easy to review.`,
+	Args: cobra.NoArgs,
+	Run:  runUpgrade,
+}
+
+var (
+	flagUpgradeDir string
+	flagUpgradeDry bool
+)
+
+func init() {
+	rootCmd.AddCommand(upgradeCmd)
+
+	upgradeCmd.Flags().StringVarP(&flagUpgradeDir, "dir", "d", ".", "Root directory of the gossamer project")
+	upgradeCmd.Flags().BoolVar(&flagUpgradeDry, "dry-run", false, "Show what would change without changing any files")
+	addTemplatesFlag(upgradeCmd)
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) {
+	upgrader, err := generator.NewUpgrader(flagUpgradeDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+		os.Exit(1)
+	}
+	useTemplates(upgrader)
+
+	from := upgrader.Manifest().GossamerVersion
+	if flagUpgradeDry {
+		fmt.Println(color.YellowString("🔍 Dry run mode - showing what would be changed:"))
+		results, err := upgrader.Plan()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, color.RedString("Error: %v\n"), err)
+			os.Exit(1)
+		}
+		printUpgradeResults(results)
+		return
+	}
+
+	fmt.Println(color.CyanString("🚀 Upgrading project from gossamer %s to %s...", from, version.Version))
+
+	results, err := upgrader.Apply()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, color.RedString("Error upgrading project: %v\n"), err)
+		os.Exit(1)
+	}
+	if printUpgradeAttention(printUpgradeResults(results)) {
+		return
+	}
+
+	fmt.Println(color.GreenString("\n✅ Project upgraded successfully!"))
+}
+
+// printUpgradeResults lists every file the upgrade changes, or would change,
+// followed by a summary, and returns the number of files per action.
+func printUpgradeResults(results []generator.UpgradeResult) map[generator.UpgradeAction]int {
+	counts := map[generator.UpgradeAction]int{}
+	for _, r := range results {
+		counts[r.Action]++
+		note := overlayNote(r.FromOverlay)
+		switch r.Action {
+		case generator.ActionCreated:
+			fmt.Printf("  📄 %s%s\n", r.Path, note)
+		case generator.ActionUpdated:
+			fmt.Printf("  ✏️  %s%s\n", r.Path, note)
+		case generator.ActionMerged:
+			fmt.Printf("  🔀 %s (merged)%s\n", r.Path, note)
+		case generator.ActionConflict:
+			fmt.Print(color.RedString("  ❗ %s (%d conflict(s))\n", r.Path, r.Conflicts))
+		case generator.ActionRejected:
+			fmt.Print(color.YellowString("  ❗ %s (new version in %s.rej)\n", r.Path, r.Path))
+		case generator.ActionSkipped:
+			fmt.Printf("  ⏭️  %s (deleted locally)\n", r.Path)
+		}
+	}
+
+	fmt.Printf("\n%d created, %d updated, %d merged, %d conflicted, %d rejected, %d unchanged\n",
+		counts[generator.ActionCreated],
+		counts[generator.ActionUpdated],
+		counts[generator.ActionMerged],
+		counts[generator.ActionConflict],
+		counts[generator.ActionRejected],
+		counts[generator.ActionUnchanged]+counts[generator.ActionKept],
+	)
+
+	return counts
+}
+
+// printUpgradeAttention lists the follow-up work left by conflicted and
+// rejected files, and reports whether there is any.
+func printUpgradeAttention(counts map[generator.UpgradeAction]int) bool {
+	conflicts, rejected := counts[generator.ActionConflict], counts[generator.ActionRejected]
+	if conflicts == 0 && rejected == 0 {
+		return false
+	}
+
+	fmt.Println(color.YellowString("\n⚠️  Some files need attention:"))
+	if conflicts > 0 {
+		fmt.Printf("  - resolve the conflict markers in %d file(s)\n", conflicts)
+	}
+	if rejected > 0 {
+		fmt.Printf("  - apply the changes from %d .rej file(s) by hand, then delete them\n", rejected)
+	}
+	return true
+}
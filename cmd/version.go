@@ -9,6 +9,8 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+
+	"github.com/cumulusware/gossamer/internal/version"
 )
 
 func init() {
@@ -20,6 +22,6 @@ var versionCmd = &cobra.Command{
 	Short: "Display version",
 	Long:  `Display Gossamer's version.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("gossamer v0.0.1")
+		fmt.Println("gossamer " + version.Version)
 	},
 }
@@ -6,6 +6,7 @@ require (
 	github.com/charmbracelet/huh v0.7.0
 	github.com/fatih/color v1.18.0
 	github.com/spf13/cobra v1.9.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
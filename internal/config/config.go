@@ -6,18 +6,124 @@
 // Package config provides the configuration information for a new project.
 package config
 
-import "time"
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDescription is used when no project description is given.
+const DefaultDescription = "A modern Go web application"
+
+// SupportedDatabases lists the valid values for ProjectConfig.DatabaseType.
+var SupportedDatabases = []string{"postgresql", "mysql", "sqlite"}
+
+// Module is a Go module required by generated projects.
+type Module struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+// commonModules are required by every generated project.
+var commonModules = []Module{
+	{Path: "github.com/joho/godotenv", Version: "v1.5.1"},
+	{Path: "github.com/justinas/nosurf", Version: "v1.2.0"},
+	{Path: "github.com/pressly/goose/v3", Version: "v3.24.3"},
+	{Path: "github.com/google/uuid", Version: "v1.6.0"},
+	{Path: "golang.org/x/crypto", Version: "v0.39.0"},
+	{Path: "github.com/prometheus/client_golang", Version: "v1.22.0"},
+	{Path: "github.com/mfridman/interpolate", Version: "v0.0.2", Indirect: true},
+	{Path: "github.com/sethvargo/go-retry", Version: "v0.3.0", Indirect: true},
+	{Path: "go.uber.org/multierr", Version: "v1.11.0", Indirect: true},
+	{Path: "golang.org/x/sync", Version: "v0.15.0", Indirect: true},
+	{Path: "github.com/beorn7/perks", Version: "v1.0.1", Indirect: true},
+	{Path: "github.com/cespare/xxhash/v2", Version: "v2.3.0", Indirect: true},
+	{Path: "github.com/munnerz/goautoneg", Version: "v0.0.0-20191010083416-a7dc8b61c822", Indirect: true},
+	{Path: "github.com/prometheus/client_model", Version: "v0.6.1", Indirect: true},
+	{Path: "github.com/prometheus/common", Version: "v0.62.0", Indirect: true},
+	{Path: "github.com/prometheus/procfs", Version: "v0.16.1", Indirect: true},
+	{Path: "golang.org/x/sys", Version: "v0.33.0", Indirect: true},
+	{Path: "google.golang.org/protobuf", Version: "v1.36.6", Indirect: true},
+}
+
+// databaseModules lists the modules required for each supported database,
+// starting with the driver.
+var databaseModules = map[string][]Module{
+	"postgresql": {
+		{Path: "github.com/jackc/pgx/v5", Version: "v5.7.5"},
+		{Path: "github.com/jackc/pgpassfile", Version: "v1.0.0", Indirect: true},
+		{Path: "github.com/jackc/pgservicefile", Version: "v0.0.0-20240606120523-5a60cdf6a761", Indirect: true},
+		{Path: "github.com/jackc/puddle/v2", Version: "v2.2.2", Indirect: true},
+		{Path: "golang.org/x/text", Version: "v0.26.0", Indirect: true},
+	},
+	"mysql": {
+		{Path: "github.com/go-sql-driver/mysql", Version: "v1.9.2"},
+		{Path: "filippo.io/edwards25519", Version: "v1.1.0", Indirect: true},
+	},
+	"sqlite": {
+		{Path: "modernc.org/sqlite", Version: "v1.37.0"},
+		{Path: "github.com/dustin/go-humanize", Version: "v1.0.1", Indirect: true},
+		{Path: "github.com/mattn/go-isatty", Version: "v0.0.20", Indirect: true},
+		{Path: "github.com/ncruces/go-strftime", Version: "v0.1.9", Indirect: true},
+		{Path: "github.com/remyoudompheng/bigfft", Version: "v0.0.0-20230129092748-24d4a6f8daec", Indirect: true},
+		{Path: "golang.org/x/exp", Version: "v0.0.0-20250506013437-ce4c2cf36ca6", Indirect: true},
+		{Path: "golang.org/x/mod", Version: "v0.25.0", Indirect: true},
+		{Path: "modernc.org/libc", Version: "v1.65.0", Indirect: true},
+		{Path: "modernc.org/mathutil", Version: "v1.7.1", Indirect: true},
+		{Path: "modernc.org/memory", Version: "v1.10.0", Indirect: true},
+	},
+}
+
+// tracingModules are required by projects that include tracing.
+var tracingModules = []Module{
+	{Path: "go.opentelemetry.io/otel", Version: "v1.36.0"},
+	{Path: "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp", Version: "v1.36.0"},
+	{Path: "go.opentelemetry.io/otel/exporters/stdout/stdouttrace", Version: "v1.36.0"},
+	{Path: "go.opentelemetry.io/otel/sdk", Version: "v1.36.0"},
+	{Path: "go.opentelemetry.io/otel/trace", Version: "v1.36.0"},
+	{Path: "github.com/cenkalti/backoff/v5", Version: "v5.0.2", Indirect: true},
+	{Path: "github.com/go-logr/logr", Version: "v1.4.2", Indirect: true},
+	{Path: "github.com/go-logr/stdr", Version: "v1.2.2", Indirect: true},
+	{Path: "github.com/grpc-ecosystem/grpc-gateway/v2", Version: "v2.26.3", Indirect: true},
+	{Path: "go.opentelemetry.io/auto/sdk", Version: "v1.1.0", Indirect: true},
+	{Path: "go.opentelemetry.io/otel/exporters/otlp/otlptrace", Version: "v1.36.0", Indirect: true},
+	{Path: "go.opentelemetry.io/otel/metric", Version: "v1.36.0", Indirect: true},
+	{Path: "go.opentelemetry.io/proto/otlp", Version: "v1.6.0", Indirect: true},
+	{Path: "golang.org/x/net", Version: "v0.40.0", Indirect: true},
+	{Path: "golang.org/x/text", Version: "v0.26.0", Indirect: true},
+	{Path: "google.golang.org/genproto/googleapis/api", Version: "v0.0.0-20250519155744-55703ea1f237", Indirect: true},
+	{Path: "google.golang.org/genproto/googleapis/rpc", Version: "v0.0.0-20250519155744-55703ea1f237", Indirect: true},
+	{Path: "google.golang.org/grpc", Version: "v1.72.1", Indirect: true},
+}
 
 type ProjectConfig struct {
-	Name         string
-	EnvName      string
-	ModulePath   string
-	IncludeHTMX  bool
-	IncludeAPI   bool
-	DatabaseType string
-	Author       string
-	Description  string
-	Year         int
+	Name           string `yaml:"name"`
+	EnvName        string `yaml:"env_name,omitempty"`
+	ModulePath     string `yaml:"module_path"`
+	IncludeHTMX    bool   `yaml:"include_htmx"`
+	IncludeAPI     bool   `yaml:"include_api"`
+	IncludeRBAC    bool   `yaml:"include_rbac"`
+	IncludeJobs    bool   `yaml:"include_jobs"`
+	IncludeTracing bool   `yaml:"include_tracing"`
+	DatabaseType   string `yaml:"database_type"`
+	Author         string `yaml:"author,omitempty"`
+	Description    string `yaml:"description,omitempty"`
+	Year           int    `yaml:"year,omitempty"`
+
+	// Blueprint is the name of the blueprint the project is generated from;
+	// empty for the built-in blueprint. Options holds the values of the
+	// options the blueprint defines.
+	Blueprint string         `yaml:"blueprint,omitempty"`
+	Options   map[string]any `yaml:"options,omitempty"`
 }
 
 func NewProjectConfig() ProjectConfig {
@@ -27,15 +133,177 @@ func NewProjectConfig() ProjectConfig {
 	}
 }
 
-func (pc *ProjectConfig) GetDependencies() []string {
-	deps := []string{
-		"github.com/jackc/pgx/v5",
-		"github.com/joho/godotenv",
-		"github.com/justinas/nosurf",
-		"github.com/pressly/goose/v3",
-		"github.com/google/uuid",
-		"golang.org/x/crypto",
+// LoadFile reads a YAML project configuration file. Fields missing from the
+// file keep the values from NewProjectConfig; unknown fields are an error so
+// that typos do not silently fall back to defaults.
+func LoadFile(path string) (*ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg := NewProjectConfig()
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// ApplyDefaults fills in the fields that can be derived from the project
+// name, leaving any values that have already been set untouched.
+func (pc *ProjectConfig) ApplyDefaults() {
+	if pc.EnvName == "" {
+		pc.EnvName = strings.ToUpper(strings.ReplaceAll(pc.Name, "-", "_"))
+	}
+	if pc.ModulePath == "" {
+		pc.ModulePath = strings.ReplaceAll(pc.Name, "-", "")
+	}
+	if pc.Description == "" {
+		pc.Description = DefaultDescription
+	}
+	if pc.DatabaseType == "" {
+		pc.DatabaseType = "postgresql"
+	}
+	if pc.Year == 0 {
+		pc.Year = time.Now().Year()
+	}
+}
+
+// Validate checks every field of the configuration and returns all of the
+// problems found joined into a single error.
+func (pc *ProjectConfig) Validate() error {
+	var errs []error
+
+	if err := ValidateProjectName(pc.Name); err != nil {
+		errs = append(errs, fmt.Errorf("name: %w", err))
+	}
+	if err := ValidateModulePath(pc.ModulePath); err != nil {
+		errs = append(errs, fmt.Errorf("module path: %w", err))
+	}
+	if err := ValidateDatabaseType(pc.DatabaseType); err != nil {
+		errs = append(errs, fmt.Errorf("database: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateProjectName checks that the name can be used as a directory name.
+func ValidateProjectName(val string) error {
+	if val == "" {
+		return fmt.Errorf("project name cannot be empty")
+	}
+
+	// Check for valid directory name
+	matched, _ := regexp.MatchString(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`, val)
+	if !matched {
+		return fmt.Errorf(
+			"project name must start with letter/number and contain only letters, numbers, hyphens, and underscores",
+		)
+	}
+
+	// Check for reserved names
+	reserved := []string{"go", "test", "main", "src", "pkg", "cmd", "internal"}
+	for _, r := range reserved {
+		if strings.EqualFold(val, r) {
+			return fmt.Errorf("'%s' is a reserved name", val)
+		}
+	}
+
+	return nil
+}
+
+// ValidateModulePath performs a basic sanity check of a Go module path.
+func ValidateModulePath(val string) error {
+	if val == "" {
+		return fmt.Errorf("module path cannot be empty")
+	}
+
+	// Basic validation for Go module path
+	matched, _ := regexp.MatchString(`^[a-zA-Z0-9][a-zA-Z0-9._/-]*[a-zA-Z0-9]$`, val)
+	if !matched {
+		return fmt.Errorf("invalid module path format")
+	}
+
+	return nil
+}
+
+// ValidateDatabaseType checks that the database is one gossamer can generate.
+func ValidateDatabaseType(val string) error {
+	for _, db := range SupportedDatabases {
+		if val == db {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"unsupported database %q (supported: %s)",
+		val,
+		strings.Join(SupportedDatabases, ", "),
+	)
+}
+
+// Modules returns the modules required by the project: the modules for its
+// database, driver first, followed by the modules every project uses and
+// those of the optional features it includes. Modules shared with an
+// earlier list are only returned once.
+func (pc *ProjectConfig) Modules() []Module {
+	modules := append([]Module{}, databaseModules[pc.DatabaseType]...)
+	modules = append(modules, commonModules...)
+	if pc.IncludeTracing {
+		modules = appendMissing(modules, tracingModules)
+	}
+	return modules
+}
+
+// appendMissing appends the modules whose path is not in modules yet.
+func appendMissing(modules, more []Module) []Module {
+	seen := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		seen[m.Path] = true
+	}
+	for _, m := range more {
+		if !seen[m.Path] {
+			modules = append(modules, m)
+		}
+	}
+	return modules
+}
+
+// Requirements returns the modules imported directly by the project, sorted
+// by path as they appear in go.mod.
+func (pc *ProjectConfig) Requirements() []Module {
+	return pc.requirements(false)
+}
+
+// IndirectRequirements returns the modules the project only needs through
+// its direct requirements, sorted by path.
+func (pc *ProjectConfig) IndirectRequirements() []Module {
+	return pc.requirements(true)
+}
+
+func (pc *ProjectConfig) requirements(indirect bool) []Module {
+	var modules []Module
+	for _, m := range pc.Modules() {
+		if m.Indirect == indirect {
+			modules = append(modules, m)
+		}
 	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Path < modules[j].Path
+	})
+	return modules
+}
 
+// GetDependencies returns the paths of the modules imported directly by the
+// project, starting with the database driver.
+func (pc *ProjectConfig) GetDependencies() []string {
+	var deps []string
+	for _, m := range pc.Modules() {
+		if !m.Indirect {
+			deps = append(deps, m.Path)
+		}
+	}
 	return deps
 }
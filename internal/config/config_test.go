@@ -6,6 +6,10 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -37,6 +41,7 @@ func TestGetDependencies(t *testing.T) {
 		"github.com/pressly/goose/v3",
 		"github.com/google/uuid",
 		"golang.org/x/crypto",
+		"github.com/prometheus/client_golang",
 	}
 
 	if len(deps) != len(expectedDeps) {
@@ -49,3 +54,224 @@ func TestGetDependencies(t *testing.T) {
 		}
 	}
 }
+
+func TestGetDependenciesMySQL(t *testing.T) {
+	cfg := NewProjectConfig()
+	cfg.DatabaseType = "mysql"
+	deps := cfg.GetDependencies()
+
+	if deps[0] != "github.com/go-sql-driver/mysql" {
+		t.Errorf("Expected the MySQL driver first, got '%s'", deps[0])
+	}
+	for _, dep := range deps {
+		if dep == "github.com/jackc/pgx/v5" {
+			t.Error("Expected no PostgreSQL driver for a MySQL project")
+		}
+	}
+}
+
+func TestGetDependenciesTracing(t *testing.T) {
+	cfg := NewProjectConfig()
+	if slices.Contains(cfg.GetDependencies(), "go.opentelemetry.io/otel") {
+		t.Error("Expected no OpenTelemetry modules without tracing")
+	}
+
+	cfg.IncludeTracing = true
+	if !slices.Contains(cfg.GetDependencies(), "go.opentelemetry.io/otel") {
+		t.Error("Expected the OpenTelemetry modules with tracing")
+	}
+}
+
+func TestRequirements(t *testing.T) {
+	for _, db := range SupportedDatabases {
+		cfg := NewProjectConfig()
+		cfg.DatabaseType = db
+		cfg.IncludeTracing = true
+
+		seen := make(map[string]bool)
+		for _, modules := range [][]Module{cfg.Requirements(), cfg.IndirectRequirements()} {
+			for i, m := range modules {
+				if seen[m.Path] {
+					t.Errorf("%s: module %s is required more than once", db, m.Path)
+				}
+				seen[m.Path] = true
+				if m.Version == "" {
+					t.Errorf("%s: module %s has no version", db, m.Path)
+				}
+				if i > 0 && modules[i-1].Path > m.Path {
+					t.Errorf("%s: modules not sorted: %s before %s", db, modules[i-1].Path, m.Path)
+				}
+			}
+		}
+
+		if len(cfg.Requirements()) != len(cfg.GetDependencies()) {
+			t.Errorf("%s: expected %d direct requirements, got %d",
+				db, len(cfg.GetDependencies()), len(cfg.Requirements()))
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.yaml")
+	data := `name: my-app
+module_path: github.com/acme/my-app
+include_api: true
+author: Jane Doe
+`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+
+	if cfg.Name != "my-app" {
+		t.Errorf("Expected Name 'my-app', got '%s'", cfg.Name)
+	}
+	if cfg.ModulePath != "github.com/acme/my-app" {
+		t.Errorf("Expected ModulePath 'github.com/acme/my-app', got '%s'", cfg.ModulePath)
+	}
+	if !cfg.IncludeAPI {
+		t.Error("Expected IncludeAPI to be true")
+	}
+	if cfg.IncludeHTMX {
+		t.Error("Expected IncludeHTMX to be false")
+	}
+	if cfg.DatabaseType != "postgresql" {
+		t.Errorf("Expected default DatabaseType 'postgresql', got '%s'", cfg.DatabaseType)
+	}
+}
+
+func TestLoadFileUnknownField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.yaml")
+	if err := os.WriteFile(path, []byte("name: my-app\nincude_api: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	if _, err := LoadFile(path); err == nil {
+		t.Error("Expected error for unknown field, got nil")
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	cfg := ProjectConfig{Name: "my-web-app"}
+	cfg.ApplyDefaults()
+
+	if cfg.EnvName != "MY_WEB_APP" {
+		t.Errorf("Expected EnvName 'MY_WEB_APP', got '%s'", cfg.EnvName)
+	}
+	if cfg.ModulePath != "mywebapp" {
+		t.Errorf("Expected ModulePath 'mywebapp', got '%s'", cfg.ModulePath)
+	}
+	if cfg.Description != DefaultDescription {
+		t.Errorf("Expected default Description, got '%s'", cfg.Description)
+	}
+	if cfg.DatabaseType != "postgresql" {
+		t.Errorf("Expected DatabaseType 'postgresql', got '%s'", cfg.DatabaseType)
+	}
+
+	// Values already set must be kept
+	cfg = ProjectConfig{Name: "my-web-app", ModulePath: "github.com/acme/web"}
+	cfg.ApplyDefaults()
+	if cfg.ModulePath != "github.com/acme/web" {
+		t.Errorf("Expected ModulePath to be kept, got '%s'", cfg.ModulePath)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    ProjectConfig
+		wantError []string
+	}{
+		{
+			name: "valid",
+			config: ProjectConfig{
+				Name:         "my-app",
+				ModulePath:   "github.com/acme/my-app",
+				DatabaseType: "postgresql",
+			},
+		},
+		{
+			name: "all invalid",
+			config: ProjectConfig{
+				Name:         "",
+				ModulePath:   "bad path",
+				DatabaseType: "oracle",
+			},
+			wantError: []string{"name:", "module path:", "database:"},
+		},
+		{
+			name: "unsupported database",
+			config: ProjectConfig{
+				Name:         "my-app",
+				ModulePath:   "my-app",
+				DatabaseType: "oracle",
+			},
+			wantError: []string{"database:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if len(tt.wantError) == 0 {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate() expected error, got nil")
+			}
+			for _, want := range tt.wantError {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	options := []Option{
+		{Name: "Audit", Type: OptionBool, Default: true},
+		{Name: "Team", Type: OptionChoice, Choices: []string{"platform", "finance"}},
+		{Name: "Company"},
+	}
+	for _, o := range options {
+		if err := o.Check(); err != nil {
+			t.Errorf("Check(%s) error = %v", o.Name, err)
+		}
+	}
+
+	cfg := NewProjectConfig()
+	cfg.Options = map[string]any{"Company": "ACME"}
+	cfg.ApplyOptionDefaults(options)
+
+	if cfg.Options["Audit"] != true || cfg.Options["Team"] != "platform" || cfg.Options["Company"] != "ACME" {
+		t.Errorf("Unexpected options after applying defaults: %v", cfg.Options)
+	}
+	if err := cfg.ValidateOptions(options); err != nil {
+		t.Errorf("ValidateOptions() error = %v", err)
+	}
+
+	cfg.Options["Team"] = "sales"
+	cfg.Options["Audit"] = "yes"
+	cfg.Options["Colour"] = "blue"
+	err := cfg.ValidateOptions(options)
+	if err == nil {
+		t.Fatal("Expected errors for invalid option values")
+	}
+	for _, want := range []string{"Audit must be true or false", "Team must be one of platform, finance", "unknown option Colour"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got %v", want, err)
+		}
+	}
+
+	if err := (Option{Name: "Team", Type: OptionChoice, Default: "sales", Choices: []string{"platform"}}).Check(); err == nil {
+		t.Error("Expected an error for a default that is not one of the choices")
+	}
+}
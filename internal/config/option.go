@@ -0,0 +1,150 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Option types supported by blueprints.
+const (
+	OptionString = "string" // Free text, the default
+	OptionBool   = "bool"   // Yes or no
+	OptionChoice = "choice" // One of Option.Choices
+)
+
+// optionNamePattern matches option names that can be used in templates.
+var optionNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Option is a project setting defined by a blueprint in addition to the
+// fields of ProjectConfig. Its value is available to the blueprint's
+// templates as {{.Options.<name>}}.
+type Option struct {
+	Name        string   `yaml:"name"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description,omitempty"`
+	Type        string   `yaml:"type,omitempty"`
+	Choices     []string `yaml:"choices,omitempty"`
+	Default     any      `yaml:"default,omitempty"`
+}
+
+// Check reports whether the option definition itself is valid.
+func (o Option) Check() error {
+	if !optionNamePattern.MatchString(o.Name) {
+		return fmt.Errorf("option name %q must be a valid identifier", o.Name)
+	}
+	if _, ok := reflect.TypeOf(ProjectConfig{}).FieldByName(o.Name); ok {
+		return fmt.Errorf("option name %q is already a project setting", o.Name)
+	}
+
+	switch o.Type {
+	case "", OptionString, OptionBool:
+	case OptionChoice:
+		if len(o.Choices) == 0 {
+			return fmt.Errorf("option %s: a choice needs at least one choice", o.Name)
+		}
+	default:
+		return fmt.Errorf(
+			"option %s: unknown type %q (supported: %s, %s, %s)",
+			o.Name, o.Type, OptionString, OptionBool, OptionChoice,
+		)
+	}
+
+	if o.Default != nil {
+		if err := o.Validate(o.Default); err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that val is a valid value for the option.
+func (o Option) Validate(val any) error {
+	switch o.Type {
+	case OptionBool:
+		if _, ok := val.(bool); !ok {
+			return fmt.Errorf("option %s must be true or false", o.Name)
+		}
+	case OptionChoice:
+		s, ok := val.(string)
+		if !ok || !slices.Contains(o.Choices, s) {
+			return fmt.Errorf(
+				"option %s must be one of %s",
+				o.Name,
+				strings.Join(o.Choices, ", "),
+			)
+		}
+	default:
+		if _, ok := val.(string); !ok {
+			return fmt.Errorf("option %s must be a string", o.Name)
+		}
+	}
+	return nil
+}
+
+// ZeroValue returns the value used for the option when neither the user nor
+// the blueprint gives one.
+func (o Option) ZeroValue() any {
+	switch o.Type {
+	case OptionBool:
+		return false
+	case OptionChoice:
+		return o.Choices[0]
+	}
+	return ""
+}
+
+// ApplyOptionDefaults sets every option without a value to its default.
+func (pc *ProjectConfig) ApplyOptionDefaults(options []Option) {
+	for _, o := range options {
+		if _, ok := pc.Options[o.Name]; ok {
+			continue
+		}
+		if pc.Options == nil {
+			pc.Options = make(map[string]any)
+		}
+		if o.Default != nil {
+			pc.Options[o.Name] = o.Default
+		} else {
+			pc.Options[o.Name] = o.ZeroValue()
+		}
+	}
+}
+
+// ValidateOptions checks the option values against their definitions and
+// returns all of the problems found joined into a single error.
+func (pc *ProjectConfig) ValidateOptions(options []Option) error {
+	var errs []error
+	known := make(map[string]bool)
+
+	for _, o := range options {
+		known[o.Name] = true
+		if val, ok := pc.Options[o.Name]; ok {
+			if err := o.Validate(val); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	var unknown []string
+	for name := range pc.Options {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, fmt.Errorf("unknown option %s", name))
+	}
+
+	return errors.Join(errs...)
+}
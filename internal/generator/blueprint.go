@@ -0,0 +1,480 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cumulusware/gossamer/internal/config"
+)
+
+// DefaultBlueprint is the name of the blueprint built into gossamer.
+const DefaultBlueprint = "default"
+
+// BlueprintFileName is the name of the file describing a blueprint, found at
+// the root of the blueprint directory next to its templates directory.
+const BlueprintFileName = "blueprint.yaml"
+
+// blueprintNamePattern matches the names blueprints can be installed under.
+var blueprintNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Blueprint is a shape of project: the files to generate, the templates
+// they are rendered from, and the options to ask for in addition to the
+// project configuration.
+type Blueprint struct {
+	Name        string
+	Description string
+	Dir         string // Installation directory; empty for the built-in blueprint
+	Files       []ProjectFile
+	Options     []config.Option
+	templatesFS fs.FS
+}
+
+// blueprintSpec is the content of a blueprint.yaml file.
+type blueprintSpec struct {
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description"`
+	Extends     string          `yaml:"extends"`
+	Exclude     []string        `yaml:"exclude"`
+	Files       []fileSpec      `yaml:"files"`
+	Options     []config.Option `yaml:"options"`
+}
+
+// fileSpec describes one file of a blueprint. Sources ending in .gotmpl are
+// templates; all other sources are copied as is.
+type fileSpec struct {
+	Source      string `yaml:"source"`
+	Destination string `yaml:"destination"`
+	Permissions string `yaml:"permissions"`
+	Conditional string `yaml:"conditional"`
+}
+
+// BuiltinBlueprint returns the blueprint built into gossamer.
+func BuiltinBlueprint() *Blueprint {
+	return &Blueprint{
+		Name:        DefaultBlueprint,
+		Description: "Web application with authentication, optional HTMX and REST API",
+		Files:       GetProjectFiles(),
+		templatesFS: GetTemplatesFS(),
+	}
+}
+
+// TemplatesFS returns the filesystem the blueprint's files are rendered
+// from.
+func (b *Blueprint) TemplatesFS() fs.FS {
+	return b.templatesFS
+}
+
+// UserBlueprintsDir returns the directory blueprints are installed in,
+// e.g., ~/.config/gossamer/blueprints on Linux.
+func UserBlueprintsDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gossamer", "blueprints"), nil
+}
+
+// LoadBlueprint reads the blueprint in dir. The templates in its templates
+// directory shadow the built-in templates, so a blueprint only has to
+// contain the templates it adds or changes.
+func LoadBlueprint(dir string) (*Blueprint, error) {
+	data, err := os.ReadFile(filepath.Join(dir, BlueprintFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blueprint: %w", err)
+	}
+
+	var spec blueprintSpec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, BlueprintFileName), err)
+	}
+
+	b := &Blueprint{
+		Name:        spec.Name,
+		Description: spec.Description,
+		Dir:         dir,
+		Options:     spec.Options,
+		templatesFS: layerFS{upper: os.DirFS(filepath.Join(dir, "templates")), lower: GetTemplatesFS()},
+	}
+	if err := b.load(spec); err != nil {
+		return nil, fmt.Errorf("invalid blueprint in %s:\n%w", dir, err)
+	}
+
+	return b, nil
+}
+
+// load sets the files of the blueprint from spec, checking the whole spec
+// and returning all of the problems found joined into a single error.
+func (b *Blueprint) load(spec blueprintSpec) error {
+	var errs []error
+
+	switch {
+	case !blueprintNamePattern.MatchString(spec.Name):
+		errs = append(errs, fmt.Errorf("name %q must be lowercase letters, numbers, hyphens, and underscores", spec.Name))
+	case spec.Name == DefaultBlueprint:
+		errs = append(errs, fmt.Errorf("name %q is reserved for the built-in blueprint", spec.Name))
+	}
+
+	switch spec.Extends {
+	case "":
+	case DefaultBlueprint:
+		b.Files = GetProjectFiles()
+	default:
+		errs = append(errs, fmt.Errorf("extends: only the %q blueprint can be extended", DefaultBlueprint))
+	}
+
+	for _, dest := range spec.Exclude {
+		n := len(b.Files)
+		b.Files = removeDestination(b.Files, dest)
+		if len(b.Files) == n {
+			errs = append(errs, fmt.Errorf("exclude: no file with destination %s", dest))
+		}
+	}
+
+	for i, f := range spec.Files {
+		file, err := b.projectFile(f)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("files[%d]: %w", i, err))
+			continue
+		}
+		// A file replaces any inherited file with the same destination
+		b.Files = append(removeDestination(b.Files, file.GetDestinationPath()), file)
+	}
+	if len(b.Files) == 0 {
+		errs = append(errs, errors.New("files: a blueprint must generate at least one file"))
+	}
+
+	seen := make(map[string]bool)
+	for _, o := range spec.Options {
+		if err := o.Check(); err != nil {
+			errs = append(errs, err)
+		}
+		if seen[o.Name] {
+			errs = append(errs, fmt.Errorf("option %s is defined more than once", o.Name))
+		}
+		seen[o.Name] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// projectFile converts a file of the blueprint spec to a ProjectFile.
+func (b *Blueprint) projectFile(f fileSpec) (ProjectFile, error) {
+	if f.Source == "" || f.Destination == "" {
+		return nil, errors.New("source and destination are required")
+	}
+	if !filepath.IsLocal(f.Destination) {
+		return nil, fmt.Errorf("destination %s must be a relative path inside the project", f.Destination)
+	}
+	if _, err := fs.Stat(b.templatesFS, f.Source); err != nil {
+		return nil, fmt.Errorf("source %s not found in the blueprint or built-in templates", f.Source)
+	}
+
+	perm := fs.FileMode(0644)
+	if f.Permissions != "" {
+		p, err := strconv.ParseUint(f.Permissions, 8, 32)
+		if err != nil || p > 0777 {
+			return nil, fmt.Errorf("permissions %q must be octal, e.g., \"0755\"", f.Permissions)
+		}
+		perm = fs.FileMode(p)
+	}
+
+	if strings.HasSuffix(f.Source, ".gotmpl") {
+		return TemplateFile{
+			SourcePath:      f.Source,
+			DestinationPath: f.Destination,
+			Permissions:     perm,
+			Conditional:     f.Conditional,
+		}, nil
+	}
+	return StaticFile{
+		SourcePath:      f.Source,
+		DestinationPath: f.Destination,
+		Permissions:     perm,
+		Conditional:     f.Conditional,
+	}, nil
+}
+
+// removeDestination returns files without those generating dest.
+func removeDestination(files []ProjectFile, dest string) []ProjectFile {
+	var result []ProjectFile
+	for _, file := range files {
+		if file.GetDestinationPath() != dest {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+// FindBlueprint returns the built-in blueprint for an empty name or
+// "default", and the installed blueprint with the name otherwise.
+func FindBlueprint(name string) (*Blueprint, error) {
+	if name == "" || name == DefaultBlueprint {
+		return BuiltinBlueprint(), nil
+	}
+
+	root, err := UserBlueprintsDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(root, name)
+	if _, err := os.Stat(filepath.Join(dir, BlueprintFileName)); err != nil {
+		return nil, fmt.Errorf("blueprint %q is not installed (see gossamer blueprint list)", name)
+	}
+
+	return LoadBlueprint(dir)
+}
+
+// ListBlueprints returns the built-in blueprint followed by the installed
+// blueprints sorted by name.
+func ListBlueprints() ([]*Blueprint, error) {
+	blueprints := []*Blueprint{BuiltinBlueprint()}
+
+	root, err := UserBlueprintsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return blueprints, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blueprints directory: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, entry := range entries {
+		// Skip files and unfinished installations
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		b, err := LoadBlueprint(filepath.Join(root, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		blueprints = append(blueprints, b)
+	}
+
+	return blueprints, nil
+}
+
+// InstallBlueprint installs the blueprint in src, which is either a
+// directory or a .tar.gz, .tgz, or .zip archive, under the name given in
+// its blueprint.yaml. An installed blueprint with the same name is only
+// replaced when force is set.
+func InstallBlueprint(src string, force bool) (*Blueprint, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blueprint: %w", err)
+	}
+
+	root, err := UserBlueprintsDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create blueprints directory: %w", err)
+	}
+
+	// Unpack next to the installed blueprints so it can be moved into place
+	tmp, err := os.MkdirTemp(root, ".install-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	lower := strings.ToLower(src)
+	switch {
+	case info.IsDir():
+		err = os.CopyFS(tmp, os.DirFS(src))
+	case strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz"):
+		err = extractTarGz(src, tmp)
+	case strings.HasSuffix(lower, ".zip"):
+		err = extractZip(src, tmp)
+	default:
+		err = errors.New("a blueprint must be a directory or a .tar.gz, .tgz, or .zip archive")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack blueprint: %w", err)
+	}
+
+	dir, err := blueprintRoot(tmp)
+	if err != nil {
+		return nil, err
+	}
+	b, err := LoadBlueprint(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	dest := filepath.Join(root, b.Name)
+	if _, err := os.Stat(dest); err == nil {
+		if !force {
+			return nil, fmt.Errorf("blueprint %q is already installed (use --force to replace it)", b.Name)
+		}
+		if err := os.RemoveAll(dest); err != nil {
+			return nil, fmt.Errorf("failed to remove installed blueprint: %w", err)
+		}
+	}
+	if err := os.Rename(dir, dest); err != nil {
+		return nil, fmt.Errorf("failed to install blueprint: %w", err)
+	}
+
+	return LoadBlueprint(dest)
+}
+
+// RemoveBlueprint deletes the installed blueprint with the name.
+func RemoveBlueprint(name string) error {
+	if name == DefaultBlueprint {
+		return errors.New("the built-in blueprint cannot be removed")
+	}
+	if !blueprintNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid blueprint name %q", name)
+	}
+
+	root, err := UserBlueprintsDir()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(root, name)
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("blueprint %q is not installed", name)
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// blueprintRoot returns dir if it contains a blueprint.yaml, or else its
+// only subdirectory if that does, as archives are often created from the
+// parent of the blueprint directory.
+func blueprintRoot(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, BlueprintFileName)); err == nil {
+		return dir, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		sub := filepath.Join(dir, entries[0].Name())
+		if _, err := os.Stat(filepath.Join(sub, BlueprintFileName)); err == nil {
+			return sub, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s not found in blueprint", BlueprintFileName)
+}
+
+// extractTarGz unpacks the regular files and directories of a gzipped tar
+// archive into dir.
+func extractTarGz(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Directories are created along with the files they contain
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := writeArchiveFile(dir, hdr.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// extractZip unpacks the files of a zip archive into dir.
+func extractZip(archive, dir string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = writeArchiveFile(dir, zf.Name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// archivePath returns the path in dir for an archive entry, refusing entries
+// that would end up outside of dir.
+func archivePath(dir, name string) (string, error) {
+	name = path.Clean(strings.TrimPrefix(name, "./"))
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return "", fmt.Errorf("archive entry %s is outside of the blueprint", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(name)), nil
+}
+
+// writeArchiveFile writes the content of an archive entry into dir.
+func writeArchiveFile(dir, name string, r io.Reader) error {
+	fullPath, err := archivePath(dir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(fullPath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cumulusware/gossamer/internal/config"
+)
+
+const testBlueprintSpec = `name: admin
+description: Internal admin tool
+extends: default
+exclude:
+  - internal/infrastructure/web/templates/register.gohtml
+files:
+  - source: admin/audit.gotmpl
+    destination: internal/audit/audit.go
+    conditional: Audit
+  - source: admin/run.sh
+    destination: scripts/run.sh
+    permissions: "0755"
+options:
+  - name: Audit
+    title: Record an audit log?
+    type: bool
+    default: true
+  - name: Team
+    title: Owning team
+    type: choice
+    choices: [platform, finance]
+`
+
+// testBlueprintFiles are the files of the blueprint used in the tests.
+var testBlueprintFiles = map[string]string{
+	BlueprintFileName:              "",
+	"templates/admin/audit.gotmpl": "// Package audit is owned by {{.Options.Team}}.\npackage audit\n",
+	"templates/admin/run.sh":       "#!/bin/sh\n",
+	"templates/base/readme.gotmpl": "# {{.Name}} admin tool\n",
+}
+
+// writeBlueprint creates a blueprint directory with the given spec.
+func writeBlueprint(t *testing.T, spec string) string {
+	t.Helper()
+
+	files := make(map[string]string, len(testBlueprintFiles))
+	for path, content := range testBlueprintFiles {
+		files[path] = content
+	}
+	files[BlueprintFileName] = spec
+	return writeOverlay(t, files)
+}
+
+// useBlueprintsDir points the user configuration directory, and with it the
+// installed blueprints, at a temporary directory.
+func useBlueprintsDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	blueprints, err := UserBlueprintsDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blueprints
+}
+
+func TestLoadBlueprint(t *testing.T) {
+	b, err := LoadBlueprint(writeBlueprint(t, testBlueprintSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Name != "admin" || b.Description != "Internal admin tool" {
+		t.Errorf("Unexpected name or description: %q, %q", b.Name, b.Description)
+	}
+	if len(b.Options) != 2 {
+		t.Errorf("Expected 2 options, got %d", len(b.Options))
+	}
+
+	files := make(map[string]ProjectFile)
+	for _, file := range b.Files {
+		files[file.GetDestinationPath()] = file
+	}
+	if _, ok := files["go.mod"]; !ok {
+		t.Error("Expected the files of the default blueprint to be inherited")
+	}
+	if _, ok := files["internal/infrastructure/web/templates/register.gohtml"]; ok {
+		t.Error("Expected the excluded file to be removed")
+	}
+	if audit := files["internal/audit/audit.go"]; audit == nil || !audit.IsTemplate() || audit.GetConditional() != "Audit" {
+		t.Errorf("Expected the audit template with its conditional, got %+v", audit)
+	}
+	if run := files["scripts/run.sh"]; run == nil || run.IsTemplate() || run.GetPermissions() != 0755 {
+		t.Errorf("Expected the executable static script, got %+v", run)
+	}
+}
+
+func TestLoadBlueprintInvalid(t *testing.T) {
+	spec := `name: Default
+extends: other
+exclude:
+  - missing.txt
+files:
+  - source: admin/missing.gotmpl
+    destination: ../outside.go
+  - source: admin/run.sh
+    destination: run.sh
+    permissions: rwx
+options:
+  - name: Name
+    title: Clashes with a project setting
+  - name: size
+    type: number
+`
+	_, err := LoadBlueprint(writeBlueprint(t, spec))
+	if err == nil {
+		t.Fatal("Expected an error for an invalid blueprint")
+	}
+
+	for _, want := range []string{
+		`name "Default"`,
+		"extends:",
+		"exclude: no file with destination missing.txt",
+		"files[0]: destination ../outside.go",
+		`files[1]: permissions "rwx"`,
+		`option name "Name" is already a project setting`,
+		`option size: unknown type "number"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestGenerateFromBlueprint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping file creation test in short mode")
+	}
+
+	b, err := LoadBlueprint(writeBlueprint(t, testBlueprintSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, audit := range []bool{true, false} {
+		cfg := &config.ProjectConfig{
+			Name:         "test-blueprint",
+			ModulePath:   "github.com/test/test-blueprint",
+			DatabaseType: "postgresql",
+			Blueprint:    b.Name,
+			Options:      map[string]any{"Audit": audit, "Team": "finance"},
+		}
+		gen := New(cfg)
+		gen.SetBlueprint(b)
+
+		tempDir := t.TempDir()
+		originalDir, _ := os.Getwd()
+		if err := os.Chdir(tempDir); err != nil {
+			t.Fatalf("Failed to change to temp directory: %v", err)
+		}
+		err := gen.Generate()
+		os.Chdir(originalDir)
+		if err != nil {
+			t.Fatalf("Failed to generate project: %v", err)
+		}
+
+		projectDir := filepath.Join(tempDir, cfg.Name)
+		readme, err := os.ReadFile(filepath.Join(projectDir, "README.md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(readme) != "# test-blueprint admin tool\n" {
+			t.Errorf("Expected the README from the blueprint, got %q", readme)
+		}
+
+		data, err := os.ReadFile(filepath.Join(projectDir, "internal/audit/audit.go"))
+		if !audit {
+			if err == nil {
+				t.Error("Expected no audit package when the Audit option is off")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "owned by finance") {
+			t.Errorf("Expected the option value in the audit package, got %q", data)
+		}
+	}
+}
+
+func TestInstallBlueprint(t *testing.T) {
+	root := useBlueprintsDir(t)
+	src := writeBlueprint(t, testBlueprintSpec)
+
+	b, err := InstallBlueprint(src, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Dir != filepath.Join(root, "admin") {
+		t.Errorf("Expected the blueprint to be installed in %s, got %s", filepath.Join(root, "admin"), b.Dir)
+	}
+
+	if _, err := InstallBlueprint(src, false); err == nil {
+		t.Error("Expected an error when installing the same blueprint twice")
+	}
+	if _, err := InstallBlueprint(src, true); err != nil {
+		t.Errorf("Expected --force to replace the blueprint, got %v", err)
+	}
+
+	blueprints, err := ListBlueprints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blueprints) != 2 || blueprints[0].Name != DefaultBlueprint || blueprints[1].Name != "admin" {
+		t.Errorf("Expected the default and admin blueprints, got %d blueprints", len(blueprints))
+	}
+
+	if _, err := FindBlueprint("admin"); err != nil {
+		t.Errorf("Expected to find the installed blueprint, got %v", err)
+	}
+	if err := RemoveBlueprint("admin"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindBlueprint("admin"); err == nil {
+		t.Error("Expected an error for a removed blueprint")
+	}
+	if err := RemoveBlueprint(DefaultBlueprint); err == nil {
+		t.Error("Expected an error when removing the built-in blueprint")
+	}
+}
+
+func TestInstallBlueprintArchives(t *testing.T) {
+	useBlueprintsDir(t)
+	src := writeBlueprint(t, testBlueprintSpec)
+	dir := t.TempDir()
+
+	// Both archives contain the blueprint inside a top-level directory
+	tgz := filepath.Join(dir, "admin.tgz")
+	writeTestArchive(t, tgz, src, "admin/", nil)
+	if _, err := InstallBlueprint(tgz, false); err != nil {
+		t.Fatalf("Failed to install from a tar.gz archive: %v", err)
+	}
+
+	zipPath := filepath.Join(dir, "admin.zip")
+	writeTestArchive(t, zipPath, src, "admin/", nil)
+	if _, err := InstallBlueprint(zipPath, true); err != nil {
+		t.Fatalf("Failed to install from a zip archive: %v", err)
+	}
+
+	evil := filepath.Join(dir, "evil.tgz")
+	writeTestArchive(t, evil, src, "", map[string]string{"../evil.txt": "boom"})
+	if _, err := InstallBlueprint(evil, true); err == nil {
+		t.Error("Expected an error for an archive entry outside of the blueprint")
+	}
+}
+
+// writeTestArchive writes the files of the directory src into a .tgz or .zip
+// archive, with prefix prepended to their names, along with extra entries.
+func writeTestArchive(t *testing.T, archive, src, prefix string, extra map[string]string) {
+	t.Helper()
+
+	entries := make(map[string]string)
+	for path := range testBlueprintFiles {
+		data, err := os.ReadFile(filepath.Join(src, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[prefix+path] = string(data)
+	}
+	for name, content := range extra {
+		entries[name] = content
+	}
+
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(archive, ".zip") {
+		zw := zip.NewWriter(f)
+		for name, content := range entries {
+			w, err := zw.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.Write([]byte(content))
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range entries {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(content))
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -66,7 +66,7 @@ func (sf StaticFile) IsTemplate() bool            { return false }
 
 // GetProjectFiles returns the list of all files to process (both templates and static)
 func GetProjectFiles() []ProjectFile {
-	return []ProjectFile{
+	files := []ProjectFile{
 		// Root template files
 		TemplateFile{
 			SourcePath:      "base/gitignore.gotmpl",
@@ -92,17 +92,34 @@ func GetProjectFiles() []ProjectFile {
 			SourcePath:      "base/compose.gotmpl",
 			DestinationPath: "compose.yaml",
 			Permissions:     0644,
+			Conditional:     "DatabaseType!=sqlite",
 		},
 		TemplateFile{
 			SourcePath:      "base/compose.override.gotmpl",
 			DestinationPath: "compose.override.yaml",
 			Permissions:     0644,
+			Conditional:     "DatabaseType!=sqlite",
 		},
 		TemplateFile{
 			SourcePath:      "base/compose.production.gotmpl",
 			DestinationPath: "compose.production.yaml",
 			Permissions:     0644,
 		},
+		TemplateFile{
+			SourcePath:      "base/dockerfile.gotmpl",
+			DestinationPath: "Dockerfile",
+			Permissions:     0644,
+		},
+		StaticFile{
+			SourcePath:      "base/tailwind.config.js",
+			DestinationPath: "tailwind.config.js",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "base/dockerignore.gotmpl",
+			DestinationPath: ".dockerignore",
+			Permissions:     0644,
+		},
 		TemplateFile{
 			SourcePath:      "base/air.gotmpl",
 			DestinationPath: ".air.toml",
@@ -161,6 +178,52 @@ func GetProjectFiles() []ProjectFile {
 			DestinationPath: "internal/domain/auth/service.go",
 			Permissions:     0644,
 		},
+		TemplateFile{
+			SourcePath:      "internal/domain/auth/account.gotmpl",
+			DestinationPath: "internal/domain/auth/account.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/domain/auth/account_service.gotmpl",
+			DestinationPath: "internal/domain/auth/account_service.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/domain/auth/token.gotmpl",
+			DestinationPath: "internal/domain/auth/token.go",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI",
+		},
+		TemplateFile{
+			SourcePath:      "internal/domain/auth/token_service.gotmpl",
+			DestinationPath: "internal/domain/auth/token_service.go",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI",
+		},
+		TemplateFile{
+			SourcePath:      "internal/domain/auth/token_service_test.gotmpl",
+			DestinationPath: "internal/domain/auth/token_service_test.go",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI",
+		},
+		TemplateFile{
+			SourcePath:      "internal/domain/authz/entity.gotmpl",
+			DestinationPath: "internal/domain/authz/entity.go",
+			Permissions:     0644,
+			Conditional:     "IncludeRBAC",
+		},
+		TemplateFile{
+			SourcePath:      "internal/domain/authz/repository.gotmpl",
+			DestinationPath: "internal/domain/authz/repository.go",
+			Permissions:     0644,
+			Conditional:     "IncludeRBAC",
+		},
+		TemplateFile{
+			SourcePath:      "internal/domain/authz/service.gotmpl",
+			DestinationPath: "internal/domain/authz/service.go",
+			Permissions:     0644,
+			Conditional:     "IncludeRBAC",
+		},
 
 		// Infrastructure layer templates
 		TemplateFile{
@@ -168,16 +231,163 @@ func GetProjectFiles() []ProjectFile {
 			DestinationPath: "internal/infrastructure/config/config.go",
 			Permissions:     0644,
 		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/config/config_test.gotmpl",
+			DestinationPath: "internal/infrastructure/config/config_test.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/logging/logging.gotmpl",
+			DestinationPath: "internal/infrastructure/logging/logging.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/metrics/metrics.gotmpl",
+			DestinationPath: "internal/infrastructure/metrics/metrics.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/metrics/pool.gotmpl",
+			DestinationPath: "internal/infrastructure/metrics/pool.go",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/assets/assets.gotmpl",
+			DestinationPath: "internal/infrastructure/assets/assets.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/tracing/tracing.gotmpl",
+			DestinationPath: "internal/infrastructure/tracing/tracing.go",
+			Permissions:     0644,
+			Conditional:     "IncludeTracing",
+		},
 		TemplateFile{
 			SourcePath:      "internal/infrastructure/database/postgres.gotmpl",
 			DestinationPath: "internal/infrastructure/database/postgres.go",
 			Permissions:     0644,
+			Conditional:     "DatabaseType=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/database/sqlite.gotmpl",
+			DestinationPath: "internal/infrastructure/database/sqlite.go",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=sqlite",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/database/mysql.gotmpl",
+			DestinationPath: "internal/infrastructure/database/mysql.go",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=mysql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/database/tracer.gotmpl",
+			DestinationPath: "internal/infrastructure/database/tracer.go",
+			Permissions:     0644,
+			Conditional:     "IncludeTracing && DatabaseType=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/database/migrations.gotmpl",
+			DestinationPath: "internal/infrastructure/database/migrations.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/database/migrations_test.gotmpl",
+			DestinationPath: "internal/infrastructure/database/migrations_test.go",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=sqlite",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/mail/mailer.gotmpl",
+			DestinationPath: "internal/infrastructure/mail/mailer.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/mail/smtp.gotmpl",
+			DestinationPath: "internal/infrastructure/mail/smtp.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/mail/memory.gotmpl",
+			DestinationPath: "internal/infrastructure/mail/memory.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/mail/queue.gotmpl",
+			DestinationPath: "internal/infrastructure/mail/queue.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/mail/queue_test.gotmpl",
+			DestinationPath: "internal/infrastructure/mail/queue_test.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/mail/templates.gotmpl",
+			DestinationPath: "internal/infrastructure/mail/templates.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/jobs/job.gotmpl",
+			DestinationPath: "internal/infrastructure/jobs/job.go",
+			Permissions:     0644,
+			Conditional:     "IncludeJobs",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/jobs/pool.gotmpl",
+			DestinationPath: "internal/infrastructure/jobs/pool.go",
+			Permissions:     0644,
+			Conditional:     "IncludeJobs",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/jobs/pool_test.gotmpl",
+			DestinationPath: "internal/infrastructure/jobs/pool_test.go",
+			Permissions:     0644,
+			Conditional:     "IncludeJobs",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/jobs/schedule.gotmpl",
+			DestinationPath: "internal/infrastructure/jobs/schedule.go",
+			Permissions:     0644,
+			Conditional:     "IncludeJobs",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/jobs/schedule_test.gotmpl",
+			DestinationPath: "internal/infrastructure/jobs/schedule_test.go",
+			Permissions:     0644,
+			Conditional:     "IncludeJobs",
 		},
 		TemplateFile{
 			SourcePath:      "internal/infrastructure/web/server.gotmpl",
 			DestinationPath: "internal/infrastructure/web/server.go",
 			Permissions:     0644,
 		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/templates.gotmpl",
+			DestinationPath: "internal/infrastructure/web/templates.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/view/view.gotmpl",
+			DestinationPath: "internal/infrastructure/web/view/view.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/view/view_test.gotmpl",
+			DestinationPath: "internal/infrastructure/web/view/view_test.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/view/errors.gotmpl",
+			DestinationPath: "internal/infrastructure/web/view/errors.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/health.gotmpl",
+			DestinationPath: "internal/infrastructure/web/health.go",
+			Permissions:     0644,
+		},
 		TemplateFile{
 			SourcePath:      "internal/infrastructure/web/router.gotmpl",
 			DestinationPath: "internal/infrastructure/web/router.go",
@@ -193,33 +403,131 @@ func GetProjectFiles() []ProjectFile {
 			DestinationPath: "internal/infrastructure/web/middleware/csrf.go",
 			Permissions:     0644,
 		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/middleware/secure_headers.gotmpl",
+			DestinationPath: "internal/infrastructure/web/middleware/secure_headers.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/middleware/secure_headers_test.gotmpl",
+			DestinationPath: "internal/infrastructure/web/middleware/secure_headers_test.go",
+			Permissions:     0644,
+		},
 		TemplateFile{
 			SourcePath:      "internal/infrastructure/web/middleware/logging.gotmpl",
 			DestinationPath: "internal/infrastructure/web/middleware/logging.go",
 			Permissions:     0644,
 		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/middleware/request_id.gotmpl",
+			DestinationPath: "internal/infrastructure/web/middleware/request_id.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/middleware/metrics.gotmpl",
+			DestinationPath: "internal/infrastructure/web/middleware/metrics.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/middleware/tracing.gotmpl",
+			DestinationPath: "internal/infrastructure/web/middleware/tracing.go",
+			Permissions:     0644,
+			Conditional:     "IncludeTracing",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/middleware/authz.gotmpl",
+			DestinationPath: "internal/infrastructure/web/middleware/authz.go",
+			Permissions:     0644,
+			Conditional:     "IncludeRBAC",
+		},
 
 		// Adapters layer templates
 		TemplateFile{
 			SourcePath:      "internal/adapters/repository/user_postgres.gotmpl",
 			DestinationPath: "internal/adapters/repository/user_postgres.go",
 			Permissions:     0644,
+			Conditional:     "DatabaseType=postgresql",
 		},
 		TemplateFile{
 			SourcePath:      "internal/adapters/repository/session_postgres.gotmpl",
 			DestinationPath: "internal/adapters/repository/session_postgres.go",
 			Permissions:     0644,
+			Conditional:     "DatabaseType=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/repository/user_sql.gotmpl",
+			DestinationPath: "internal/adapters/repository/user_sql.go",
+			Permissions:     0644,
+			Conditional:     "DatabaseType!=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/repository/session_sql.gotmpl",
+			DestinationPath: "internal/adapters/repository/session_sql.go",
+			Permissions:     0644,
+			Conditional:     "DatabaseType!=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/repository/account_token_postgres.gotmpl",
+			DestinationPath: "internal/adapters/repository/account_token_postgres.go",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/repository/account_token_sql.gotmpl",
+			DestinationPath: "internal/adapters/repository/account_token_sql.go",
+			Permissions:     0644,
+			Conditional:     "DatabaseType!=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/repository/job_postgres.gotmpl",
+			DestinationPath: "internal/adapters/repository/job_postgres.go",
+			Permissions:     0644,
+			Conditional:     "IncludeJobs && DatabaseType=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/repository/job_sql.gotmpl",
+			DestinationPath: "internal/adapters/repository/job_sql.go",
+			Permissions:     0644,
+			Conditional:     "IncludeJobs && DatabaseType!=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/repository/authz_postgres.gotmpl",
+			DestinationPath: "internal/adapters/repository/authz_postgres.go",
+			Permissions:     0644,
+			Conditional:     "IncludeRBAC && DatabaseType=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/repository/authz_sql.gotmpl",
+			DestinationPath: "internal/adapters/repository/authz_sql.go",
+			Permissions:     0644,
+			Conditional:     "IncludeRBAC && DatabaseType!=postgresql",
 		},
 		TemplateFile{
 			SourcePath:      "internal/adapters/handlers/web/handlers.gotmpl",
 			DestinationPath: "internal/adapters/handlers/web/handlers.go",
 			Permissions:     0644,
 		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/handlers/web/handlers_test.gotmpl",
+			DestinationPath: "internal/adapters/handlers/web/handlers_test.go",
+			Permissions:     0644,
+			Conditional:     "IncludeHTMX",
+		},
 		TemplateFile{
 			SourcePath:      "internal/adapters/handlers/web/auth_handler.gotmpl",
 			DestinationPath: "internal/adapters/handlers/web/auth_handler.go",
 			Permissions:     0644,
 		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/handlers/web/account_handler.gotmpl",
+			DestinationPath: "internal/adapters/handlers/web/account_handler.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/handlers/web/dev_mail_handler.gotmpl",
+			DestinationPath: "internal/adapters/handlers/web/dev_mail_handler.go",
+			Permissions:     0644,
+		},
 		TemplateFile{
 			SourcePath:      "internal/adapters/handlers/web/home_handler.gotmpl",
 			DestinationPath: "internal/adapters/handlers/web/home_handler.go",
@@ -228,8 +536,8 @@ func GetProjectFiles() []ProjectFile {
 
 		// HTMX-specific template files
 		TemplateFile{
-			SourcePath:      "internal/adapters/handlers/web/htmx_handler.gotmpl",
-			DestinationPath: "internal/adapters/handlers/web/htmx_handler.go",
+			SourcePath:      "internal/infrastructure/web/htmx/htmx.gotmpl",
+			DestinationPath: "internal/infrastructure/web/htmx/htmx.go",
 			Permissions:     0644,
 			Conditional:     "IncludeHTMX",
 		},
@@ -253,11 +561,53 @@ func GetProjectFiles() []ProjectFile {
 			Permissions:     0644,
 			Conditional:     "IncludeAPI",
 		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/middleware/api_auth.gotmpl",
+			DestinationPath: "internal/infrastructure/web/middleware/api_auth.go",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/middleware/cors.gotmpl",
+			DestinationPath: "internal/infrastructure/web/middleware/cors.go",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI",
+		},
+		TemplateFile{
+			SourcePath:      "internal/infrastructure/web/middleware/cors_test.gotmpl",
+			DestinationPath: "internal/infrastructure/web/middleware/cors_test.go",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/repository/api_token_postgres.gotmpl",
+			DestinationPath: "internal/adapters/repository/api_token_postgres.go",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI && DatabaseType=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/repository/api_token_sql.gotmpl",
+			DestinationPath: "internal/adapters/repository/api_token_sql.go",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI && DatabaseType!=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "internal/adapters/handlers/web/token_handler.gotmpl",
+			DestinationPath: "internal/adapters/handlers/web/token_handler.go",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI",
+		},
+		TemplateFile{
+			SourcePath:      "web-templates/tokens.gotmpl",
+			DestinationPath: "internal/infrastructure/web/templates/tokens.gohtml",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI",
+		},
 
 		// Web HTML templates
 		TemplateFile{
-			SourcePath:      "web-templates/base.gotmpl",
-			DestinationPath: "internal/infrastructure/web/templates/base.gohtml",
+			SourcePath:      "web-templates/layouts/base.gotmpl",
+			DestinationPath: "internal/infrastructure/web/templates/layouts/base.gohtml",
 			Permissions:     0644,
 		},
 		TemplateFile{
@@ -280,6 +630,38 @@ func GetProjectFiles() []ProjectFile {
 			DestinationPath: "internal/infrastructure/web/templates/dashboard.gohtml",
 			Permissions:     0644,
 		},
+		TemplateFile{
+			SourcePath:      "web-templates/forgot_password.gotmpl",
+			DestinationPath: "internal/infrastructure/web/templates/forgot_password.gohtml",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "web-templates/reset_password.gotmpl",
+			DestinationPath: "internal/infrastructure/web/templates/reset_password.gohtml",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "web-templates/verify_email.gotmpl",
+			DestinationPath: "internal/infrastructure/web/templates/verify_email.gohtml",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "web-templates/dev_mail.gotmpl",
+			DestinationPath: "internal/infrastructure/web/templates/dev_mail.gohtml",
+			Permissions:     0644,
+		},
+
+		// Email templates
+		TemplateFile{
+			SourcePath:      "mail-templates/verify_email.gotmpl",
+			DestinationPath: "internal/infrastructure/mail/templates/verify_email.gohtml",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "mail-templates/reset_password.gotmpl",
+			DestinationPath: "internal/infrastructure/mail/templates/reset_password.gohtml",
+			Permissions:     0644,
+		},
 
 		// JavaScript templates (need project name)
 		TemplateFile{
@@ -287,6 +669,11 @@ func GetProjectFiles() []ProjectFile {
 			DestinationPath: "static/js/app.js",
 			Permissions:     0644,
 		},
+		TemplateFile{
+			SourcePath:      "static/static.gotmpl",
+			DestinationPath: "static/static.go",
+			Permissions:     0644,
+		},
 
 		// Static files (copied as-is)
 		StaticFile{
@@ -294,6 +681,11 @@ func GetProjectFiles() []ProjectFile {
 			DestinationPath: "static/css/app.css",
 			Permissions:     0644,
 		},
+		StaticFile{
+			SourcePath:      "static/tailwind.css",
+			DestinationPath: "internal/infrastructure/web/tailwind.css",
+			Permissions:     0644,
+		},
 		StaticFile{
 			SourcePath:      "static/favicon.ico",
 			DestinationPath: "static/favicon.ico",
@@ -305,14 +697,226 @@ func GetProjectFiles() []ProjectFile {
 			Permissions:     0644,
 		},
 		StaticFile{
-			SourcePath:      "internal/infrastructure/database/migrations/001_users.sql",
+			SourcePath:      "internal/infrastructure/database/migrations/postgresql/001_users.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/001_create_users_table.sql",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=postgresql",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/postgresql/002_sessions.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/002_create_sessions_table.sql",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=postgresql",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/sqlite/001_users.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/001_create_users_table.sql",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=sqlite",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/sqlite/002_sessions.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/002_create_sessions_table.sql",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=sqlite",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/mysql/001_users.sql",
 			DestinationPath: "internal/infrastructure/database/migrations/001_create_users_table.sql",
 			Permissions:     0644,
+			Conditional:     "DatabaseType=mysql",
 		},
 		StaticFile{
-			SourcePath:      "internal/infrastructure/database/migrations/002_sessions.sql",
+			SourcePath:      "internal/infrastructure/database/migrations/mysql/002_sessions.sql",
 			DestinationPath: "internal/infrastructure/database/migrations/002_create_sessions_table.sql",
 			Permissions:     0644,
+			Conditional:     "DatabaseType=mysql",
+		},
+
+		// The migrations of the optional features are only created with
+		// them, but their numbers stay reserved for them either way
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/postgresql/003_api_tokens.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/003_create_api_tokens_table.sql",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI && DatabaseType=postgresql",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/postgresql/004_rbac.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/004_create_rbac_tables.sql",
+			Permissions:     0644,
+			Conditional:     "IncludeRBAC && DatabaseType=postgresql",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/postgresql/005_account_tokens.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/005_create_account_tokens_table.sql",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=postgresql",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/postgresql/006_jobs.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/006_create_jobs_table.sql",
+			Permissions:     0644,
+			Conditional:     "IncludeJobs && DatabaseType=postgresql",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/sqlite/003_api_tokens.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/003_create_api_tokens_table.sql",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI && DatabaseType=sqlite",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/sqlite/004_rbac.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/004_create_rbac_tables.sql",
+			Permissions:     0644,
+			Conditional:     "IncludeRBAC && DatabaseType=sqlite",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/sqlite/005_account_tokens.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/005_create_account_tokens_table.sql",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=sqlite",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/sqlite/006_jobs.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/006_create_jobs_table.sql",
+			Permissions:     0644,
+			Conditional:     "IncludeJobs && DatabaseType=sqlite",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/mysql/003_api_tokens.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/003_create_api_tokens_table.sql",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI && DatabaseType=mysql",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/mysql/004_rbac.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/004_create_rbac_tables.sql",
+			Permissions:     0644,
+			Conditional:     "IncludeRBAC && DatabaseType=mysql",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/mysql/005_account_tokens.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/005_create_account_tokens_table.sql",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=mysql",
+		},
+		StaticFile{
+			SourcePath:      "internal/infrastructure/database/migrations/mysql/006_jobs.sql",
+			DestinationPath: "internal/infrastructure/database/migrations/006_create_jobs_table.sql",
+			Permissions:     0644,
+			Conditional:     "IncludeJobs && DatabaseType=mysql",
+		},
+
+		// The pinned copies of htmx and its extensions, fetched by `just
+		// vendor-js`. They are listed rather than globbed so that generating
+		// a project with HTMX fails when one is missing.
+		StaticFile{
+			SourcePath:      "static/js/vendor/htmx.min.js",
+			DestinationPath: "static/js/vendor/htmx.min.js",
+			Permissions:     0644,
+			Conditional:     "IncludeHTMX",
+		},
+		StaticFile{
+			SourcePath:      "static/js/vendor/response-targets.js",
+			DestinationPath: "static/js/vendor/response-targets.js",
+			Permissions:     0644,
+			Conditional:     "IncludeHTMX",
+		},
+	}
+
+	return files
+}
+
+// buildOutputs are the generated files the project's own build overwrites,
+// such as the CSS `just css` compiles. They are generated so a new project
+// builds, but not recorded in the manifest, and upgrades only create them
+// when they are missing.
+var buildOutputs = map[string]bool{
+	"static/css/app.css": true,
+}
+
+// GetResourceFiles returns the list of files generated for a CRUD resource.
+// Destination paths are templates executed with the ResourceData.
+func GetResourceFiles() []ProjectFile {
+	return []ProjectFile{
+		// Domain layer templates
+		TemplateFile{
+			SourcePath:      "resource/domain/entity.gotmpl",
+			DestinationPath: "internal/domain/{{.Package}}/entity.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "resource/domain/repository.gotmpl",
+			DestinationPath: "internal/domain/{{.Package}}/repository.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "resource/domain/service.gotmpl",
+			DestinationPath: "internal/domain/{{.Package}}/service.go",
+			Permissions:     0644,
+		},
+
+		// Adapters layer templates
+		TemplateFile{
+			SourcePath:      "resource/adapters/repository_postgres.gotmpl",
+			DestinationPath: "internal/adapters/repository/{{.Snake}}_postgres.go",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "resource/adapters/repository_sql.gotmpl",
+			DestinationPath: "internal/adapters/repository/{{.Snake}}_sql.go",
+			Permissions:     0644,
+			Conditional:     "DatabaseType!=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "resource/adapters/web_handler.gotmpl",
+			DestinationPath: "internal/adapters/handlers/web/{{.Snake}}_handler.go",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "resource/adapters/api_handler.gotmpl",
+			DestinationPath: "internal/adapters/handlers/api/{{.Snake}}_handler.go",
+			Permissions:     0644,
+			Conditional:     "IncludeAPI",
+		},
+
+		// Migrations
+		TemplateFile{
+			SourcePath:      "resource/migration_postgresql.gotmpl",
+			DestinationPath: "internal/infrastructure/database/migrations/{{.MigrationVersion}}_create_{{.Table}}_table.sql",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=postgresql",
+		},
+		TemplateFile{
+			SourcePath:      "resource/migration_sqlite.gotmpl",
+			DestinationPath: "internal/infrastructure/database/migrations/{{.MigrationVersion}}_create_{{.Table}}_table.sql",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=sqlite",
+		},
+		TemplateFile{
+			SourcePath:      "resource/migration_mysql.gotmpl",
+			DestinationPath: "internal/infrastructure/database/migrations/{{.MigrationVersion}}_create_{{.Table}}_table.sql",
+			Permissions:     0644,
+			Conditional:     "DatabaseType=mysql",
+		},
+
+		// Web HTML templates
+		TemplateFile{
+			SourcePath:      "resource/web-templates/list.gotmpl",
+			DestinationPath: "internal/infrastructure/web/templates/{{.Snake}}_list.gohtml",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "resource/web-templates/show.gotmpl",
+			DestinationPath: "internal/infrastructure/web/templates/{{.Snake}}_show.gohtml",
+			Permissions:     0644,
+		},
+		TemplateFile{
+			SourcePath:      "resource/web-templates/edit.gotmpl",
+			DestinationPath: "internal/infrastructure/web/templates/{{.Snake}}_edit.gohtml",
+			Permissions:     0644,
 		},
 	}
 }
@@ -0,0 +1,59 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Features maps the features that can be added to an existing project to
+// the ProjectConfig field enabling them, which is also the Conditional of
+// the project files belonging to the feature.
+var Features = map[string]string{
+	"htmx":    "IncludeHTMX",
+	"api":     "IncludeAPI",
+	"rbac":    "IncludeRBAC",
+	"jobs":    "IncludeJobs",
+	"tracing": "IncludeTracing",
+}
+
+// FeatureNames returns the names of the features in Features, sorted.
+func FeatureNames() []string {
+	names := make([]string, 0, len(Features))
+	for name := range Features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AddFeature enables the named feature in the project configuration and
+// limits the upgrade to the files affected by it: the files gated by the
+// feature and the shared files, such as the router and app wiring, whose
+// content depends on it. Other files are left alone even if newer templates
+// would change them.
+func (u *Upgrader) AddFeature(name string) error {
+	field, ok := Features[name]
+	if !ok {
+		return fmt.Errorf("unknown feature %q (available: %s)", name, strings.Join(FeatureNames(), ", "))
+	}
+
+	value := reflect.ValueOf(u.config).Elem().FieldByName(field)
+	if value.Bool() {
+		return fmt.Errorf("%s is already enabled in this project", name)
+	}
+
+	if u.previous == nil {
+		previous := *u.config
+		u.previous = &previous
+	}
+	value.SetBool(true)
+
+	return nil
+}
@@ -8,6 +8,7 @@ package generator
 
 import (
 	"fmt"
+	"go/format"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -18,20 +19,42 @@ import (
 	"github.com/fatih/color"
 
 	"github.com/cumulusware/gossamer/internal/config"
+	"github.com/cumulusware/gossamer/internal/manifest"
+	"github.com/cumulusware/gossamer/internal/version"
 )
 
 type Generator struct {
 	config      *config.ProjectConfig
+	files       []ProjectFile
 	templatesFS fs.FS
 }
 
 func New(config *config.ProjectConfig) *Generator {
 	return &Generator{
 		config:      config,
+		files:       GetProjectFiles(),
 		templatesFS: GetTemplatesFS(),
 	}
 }
 
+// SetBlueprint generates the project from the files and templates of b
+// rather than those of the built-in blueprint.
+func (g *Generator) SetBlueprint(b *Blueprint) {
+	g.files = b.Files
+	g.templatesFS = b.TemplatesFS()
+}
+
+// TemplatesFS returns the templates the project is generated from.
+func (g *Generator) TemplatesFS() fs.FS {
+	return g.templatesFS
+}
+
+// SetTemplatesFS replaces the templates the project is generated from,
+// e.g., with an OverlayFS.
+func (g *Generator) SetTemplatesFS(templatesFS fs.FS) {
+	g.templatesFS = templatesFS
+}
+
 func (g *Generator) Generate() error {
 	projectPath := filepath.Join(".", g.config.Name)
 
@@ -41,7 +64,9 @@ func (g *Generator) Generate() error {
 	}
 
 	// Get all project files (both templates and static)
-	projectFiles := GetProjectFiles()
+	projectFiles := g.files
+	m := manifest.New(*g.config, version.Version)
+	m.Templates = overlayDir(g.templatesFS)
 
 	for _, file := range projectFiles {
 		// Check if this file should be included based on conditions
@@ -72,40 +97,97 @@ func (g *Generator) Generate() error {
 			return fmt.Errorf("failed to write file %s: %w", fullPath, err)
 		}
 
-		fmt.Printf("  📄 %s\n", color.GreenString(file.GetDestinationPath()))
+		if !buildOutputs[file.GetDestinationPath()] {
+			m.AddFile(file.GetDestinationPath(), []byte(content))
+			if err := manifest.SavePristine(projectPath, file.GetDestinationPath(), []byte(content)); err != nil {
+				return err
+			}
+		}
+		printFile(file.GetDestinationPath(), fromOverlay(g.templatesFS, file.GetSourcePath()))
+	}
+
+	// Record how the project was generated
+	if err := m.Save(projectPath); err != nil {
+		return err
 	}
+	fmt.Printf("  📄 %s\n", color.GreenString(manifest.FileName))
 
 	return nil
 }
 
 func (g *Generator) GetFileList() []string {
-	projectFiles := GetProjectFiles()
 	var result []string
 
-	for _, file := range projectFiles {
+	for _, file := range g.files {
 		if g.shouldIncludeFile(file) {
 			result = append(result, file.GetDestinationPath())
 		}
 	}
+	result = append(result, manifest.FileName)
 
 	return result
 }
 
+// OverlayFiles returns the files of the project that would be generated
+// from an overlay directory rather than the embedded templates.
+func (g *Generator) OverlayFiles() []string {
+	var result []string
+	for _, file := range g.files {
+		if g.shouldIncludeFile(file) && fromOverlay(g.templatesFS, file.GetSourcePath()) {
+			result = append(result, file.GetDestinationPath())
+		}
+	}
+	return result
+}
+
 func (g *Generator) shouldIncludeFile(file ProjectFile) bool {
-	conditional := file.GetConditional()
+	return evalConditional(file.GetConditional(), g.config)
+}
+
+// evalConditional reports whether a file with the given conditional should
+// be included when rendering with data, which must be a pointer to a struct.
+// A conditional is either a field name, or a comparison of a field with a
+// value such as "DatabaseType=sqlite" or "DatabaseType!=postgresql". Names
+// that are not fields of data are looked up in its Options map, if any.
+// Conditionals joined with && must all hold, e.g.
+// "IncludeAPI && DatabaseType=postgresql".
+func evalConditional(conditional string, data any) bool {
 	if conditional == "" {
 		return true
 	}
 
+	if first, rest, ok := strings.Cut(conditional, "&&"); ok {
+		return evalConditional(strings.TrimSpace(first), data) && evalConditional(strings.TrimSpace(rest), data)
+	}
+
+	name, op, value := conditional, "", ""
+	if i := strings.IndexAny(conditional, "!="); i >= 0 {
+		name = conditional[:i]
+		op, value, _ = strings.Cut(conditional[i:], "=")
+		op += "="
+	}
+
 	// Use reflection to check the config field
-	configValue := reflect.ValueOf(g.config).Elem()
-	field := configValue.FieldByName(conditional)
+	configValue := reflect.ValueOf(data).Elem()
+	field := configValue.FieldByName(name)
+	if options := configValue.FieldByName("Options"); !field.IsValid() && options.Kind() == reflect.Map {
+		if option := options.MapIndex(reflect.ValueOf(name)); option.IsValid() {
+			field = option.Elem()
+		}
+	}
 
 	if !field.IsValid() {
 		// If field doesn't exist, include the file
 		return true
 	}
 
+	switch op {
+	case "=":
+		return fmt.Sprint(field.Interface()) == value
+	case "!=":
+		return fmt.Sprint(field.Interface()) != value
+	}
+
 	// If it's a boolean field, return its value
 	if field.Kind() == reflect.Bool {
 		return field.Bool()
@@ -116,8 +198,24 @@ func (g *Generator) shouldIncludeFile(file ProjectFile) bool {
 }
 
 func (g *Generator) generateFileContent(file ProjectFile) (string, error) {
+	return renderFile(g.templatesFS, file, g.config)
+}
+
+// printFile prints a generated file, noting when it came from an overlay
+// directory.
+func printFile(path string, overlay bool) {
+	if overlay {
+		fmt.Printf("  📄 %s %s\n", color.GreenString(path), color.MagentaString("(from templates overlay)"))
+		return
+	}
+	fmt.Printf("  📄 %s\n", color.GreenString(path))
+}
+
+// renderFile reads the file from the templates filesystem and, if it is a
+// template, executes it with data.
+func renderFile(templatesFS fs.FS, file ProjectFile, data any) (string, error) {
 	// Read the file
-	content, err := fs.ReadFile(g.templatesFS, file.GetSourcePath())
+	content, err := fs.ReadFile(templatesFS, file.GetSourcePath())
 	if err != nil {
 		return "", fmt.Errorf("failed to read file %s: %w", file.GetSourcePath(), err)
 	}
@@ -127,15 +225,34 @@ func (g *Generator) generateFileContent(file ProjectFile) (string, error) {
 		return string(content), nil
 	}
 
-	// Process as Go template
-	tmpl, err := template.New(filepath.Base(file.GetSourcePath())).Parse(string(content))
+	text, err := renderTemplate(file.GetSourcePath(), string(content), data)
+	if err != nil {
+		return "", err
+	}
+
+	// Conditional sections leave uneven alignment behind, so Go files are
+	// formatted the way gofmt would
+	if strings.HasSuffix(file.GetDestinationPath(), ".go") {
+		formatted, err := format.Source([]byte(text))
+		if err != nil {
+			return "", fmt.Errorf("failed to format %s: %w", file.GetSourcePath(), err)
+		}
+		text = string(formatted)
+	}
+
+	return text, nil
+}
+
+// renderTemplate parses text as a Go template and executes it with data.
+func renderTemplate(name, text string, data any) (string, error) {
+	tmpl, err := template.New(filepath.Base(name)).Parse(text)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse template %s: %w", file.GetSourcePath(), err)
+		return "", fmt.Errorf("failed to parse template %s: %w", name, err)
 	}
 
 	var buf strings.Builder
-	if err := tmpl.Execute(&buf, g.config); err != nil {
-		return "", fmt.Errorf("failed to execute template %s: %w", file.GetSourcePath(), err)
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template %s: %w", name, err)
 	}
 
 	return buf.String(), nil
@@ -6,12 +6,15 @@
 package generator
 
 import (
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/cumulusware/gossamer/internal/config"
+	"github.com/cumulusware/gossamer/internal/manifest"
 )
 
 func TestGeneratorBasicProject(t *testing.T) {
@@ -76,7 +79,8 @@ func TestGeneratorWithHTMX(t *testing.T) {
 
 	// Check HTMX files are included
 	htmxFiles := []string{
-		"internal/adapters/handlers/web/htmx_handler.go",
+		"internal/infrastructure/web/htmx/htmx.go",
+		"internal/adapters/handlers/web/handlers_test.go",
 		"internal/infrastructure/web/templates/partials/user_info.gohtml",
 	}
 
@@ -147,10 +151,147 @@ func TestConditionalFileInclusion(t *testing.T) {
 			shouldHave: []string{
 				"go.mod",
 				"internal/app/app.go",
+				"internal/domain/auth/account_service.go",
+				"internal/infrastructure/mail/smtp.go",
+				"internal/infrastructure/mail/queue.go",
+				"internal/infrastructure/mail/queue_test.go",
+				"internal/infrastructure/config/config_test.go",
+				"internal/infrastructure/mail/templates/verify_email.gohtml",
+				"internal/adapters/repository/account_token_postgres.go",
+				"internal/infrastructure/database/migrations/005_create_account_tokens_table.sql",
+				"internal/infrastructure/web/templates/reset_password.gohtml",
+				"internal/infrastructure/web/health.go",
+				"internal/infrastructure/metrics/metrics.go",
+				"internal/infrastructure/metrics/pool.go",
+				"internal/infrastructure/database/migrations.go",
+				"internal/infrastructure/assets/assets.go",
+				"internal/infrastructure/web/templates.go",
+				"internal/infrastructure/web/view/view.go",
+				"internal/infrastructure/web/view/view_test.go",
+				"internal/infrastructure/web/middleware/secure_headers.go",
+				"internal/infrastructure/web/middleware/secure_headers_test.go",
+				"internal/infrastructure/web/templates/layouts/base.gohtml",
+				"tailwind.config.js",
+				"internal/infrastructure/web/tailwind.css",
+				"static/static.go",
+				"Dockerfile",
+				".dockerignore",
+				"compose.production.yaml",
 			},
 			shouldntHave: []string{
-				"internal/adapters/handlers/web/htmx_handler.go",
+				"internal/infrastructure/web/htmx/htmx.go",
+				"internal/adapters/handlers/web/handlers_test.go",
 				"internal/adapters/handlers/api/handlers.go",
+				"internal/infrastructure/web/middleware/cors.go",
+				"internal/infrastructure/database/sqlite.go",
+				"internal/infrastructure/database/migrations_test.go",
+				"internal/infrastructure/database/mysql.go",
+				"internal/domain/auth/token.go",
+				"internal/domain/authz/service.go",
+				"internal/infrastructure/jobs/pool.go",
+				"internal/infrastructure/tracing/tracing.go",
+				"internal/adapters/repository/api_token_postgres.go",
+				"internal/infrastructure/database/migrations/003_create_api_tokens_table.sql",
+				"internal/infrastructure/database/migrations/004_create_rbac_tables.sql",
+				"internal/infrastructure/database/migrations/006_create_jobs_table.sql",
+				"internal/infrastructure/web/templates/base.gohtml",
+			},
+		},
+		{
+			name: "sqlite",
+			config: &config.ProjectConfig{
+				Name:         "lite",
+				ModulePath:   "github.com/test/lite",
+				DatabaseType: "sqlite",
+			},
+			shouldHave: []string{
+				"internal/infrastructure/database/sqlite.go",
+				"internal/infrastructure/database/migrations_test.go",
+				"internal/adapters/repository/user_sql.go",
+				"internal/adapters/repository/session_sql.go",
+				"internal/adapters/repository/account_token_sql.go",
+				"internal/infrastructure/database/migrations/001_create_users_table.sql",
+				"compose.production.yaml",
+			},
+			shouldntHave: []string{
+				"internal/infrastructure/database/postgres.go",
+				"internal/adapters/repository/user_postgres.go",
+				"internal/adapters/repository/account_token_postgres.go",
+				"internal/infrastructure/metrics/pool.go",
+				"compose.yaml",
+			},
+		},
+		{
+			name: "mysql",
+			config: &config.ProjectConfig{
+				Name:         "my",
+				ModulePath:   "github.com/test/my",
+				DatabaseType: "mysql",
+			},
+			shouldHave: []string{
+				"internal/infrastructure/database/mysql.go",
+				"internal/adapters/repository/user_sql.go",
+				"internal/adapters/repository/session_sql.go",
+				"internal/infrastructure/database/migrations/001_create_users_table.sql",
+				"compose.yaml",
+			},
+			shouldntHave: []string{
+				"internal/infrastructure/database/postgres.go",
+				"internal/infrastructure/database/sqlite.go",
+				"internal/adapters/repository/user_postgres.go",
+				"internal/infrastructure/database/migrations/003_create_api_tokens_table.sql",
+			},
+		},
+		{
+			name: "rbac",
+			config: &config.ProjectConfig{
+				Name:         "rbac",
+				ModulePath:   "github.com/test/rbac",
+				IncludeRBAC:  true,
+				DatabaseType: "sqlite",
+			},
+			shouldHave: []string{
+				"internal/domain/authz/service.go",
+				"internal/infrastructure/web/middleware/authz.go",
+				"internal/adapters/repository/authz_sql.go",
+				"internal/infrastructure/database/migrations/004_create_rbac_tables.sql",
+			},
+			shouldntHave: []string{
+				"internal/adapters/repository/authz_postgres.go",
+				"internal/domain/auth/token.go",
+			},
+		},
+		{
+			name: "jobs",
+			config: &config.ProjectConfig{
+				Name:         "jobs",
+				ModulePath:   "github.com/test/jobs",
+				IncludeJobs:  true,
+				DatabaseType: "postgresql",
+			},
+			shouldHave: []string{
+				"internal/infrastructure/jobs/pool.go",
+				"internal/infrastructure/jobs/pool_test.go",
+				"internal/infrastructure/jobs/schedule.go",
+				"internal/adapters/repository/job_postgres.go",
+				"internal/infrastructure/database/migrations/006_create_jobs_table.sql",
+			},
+			shouldntHave: []string{
+				"internal/adapters/repository/job_sql.go",
+			},
+		},
+		{
+			name: "tracing",
+			config: &config.ProjectConfig{
+				Name:           "tracing",
+				ModulePath:     "github.com/test/tracing",
+				IncludeTracing: true,
+				DatabaseType:   "postgresql",
+			},
+			shouldHave: []string{
+				"internal/infrastructure/tracing/tracing.go",
+				"internal/infrastructure/web/middleware/tracing.go",
+				"internal/infrastructure/database/tracer.go",
 			},
 		},
 		{
@@ -165,10 +306,18 @@ func TestConditionalFileInclusion(t *testing.T) {
 			shouldHave: []string{
 				"go.mod",
 				"internal/app/app.go",
-				"internal/adapters/handlers/web/htmx_handler.go",
+				"internal/infrastructure/web/htmx/htmx.go",
 				"internal/adapters/handlers/api/handlers.go",
+				"internal/infrastructure/web/middleware/api_auth.go",
+				"internal/infrastructure/web/middleware/cors.go",
+				"internal/infrastructure/web/middleware/cors_test.go",
+				"internal/domain/auth/token_service_test.go",
+				"internal/adapters/repository/api_token_postgres.go",
+				"internal/infrastructure/database/migrations/003_create_api_tokens_table.sql",
+			},
+			shouldntHave: []string{
+				"internal/adapters/repository/api_token_sql.go",
 			},
-			shouldntHave: []string{},
 		},
 	}
 
@@ -203,6 +352,34 @@ func TestConditionalFileInclusion(t *testing.T) {
 	}
 }
 
+func TestEvalConditional(t *testing.T) {
+	cfg := &config.ProjectConfig{
+		IncludeAPI:   true,
+		DatabaseType: "mysql",
+		Options:      map[string]any{"Audit": false},
+	}
+
+	tests := []struct {
+		conditional string
+		want        bool
+	}{
+		{"", true},
+		{"IncludeAPI", true},
+		{"IncludeHTMX", false},
+		{"DatabaseType=mysql", true},
+		{"DatabaseType!=mysql", false},
+		{"Audit", false},
+		{"IncludeAPI && DatabaseType!=postgresql", true},
+		{"IncludeAPI && DatabaseType=postgresql", false},
+		{"IncludeHTMX&&IncludeAPI", false},
+	}
+	for _, tt := range tests {
+		if got := evalConditional(tt.conditional, cfg); got != tt.want {
+			t.Errorf("evalConditional(%q) = %v, want %v", tt.conditional, got, tt.want)
+		}
+	}
+}
+
 // Integration test that actually creates files
 func TestGeneratorFileCreation(t *testing.T) {
 	// Skip in CI unless we have proper temp directory setup
@@ -269,6 +446,25 @@ func TestGeneratorFileCreation(t *testing.T) {
 	if !strings.Contains(string(content), cfg.ModulePath) {
 		t.Errorf("go.mod doesn't contain expected module path %s", cfg.ModulePath)
 	}
+
+	// Verify the manifest records the config and every generated file but
+	// the build outputs
+	m, err := manifest.Load(projectPath)
+	if err != nil {
+		t.Fatalf("Failed to load project manifest: %v", err)
+	}
+	if m.Config.ModulePath != cfg.ModulePath {
+		t.Errorf("Manifest module path = %s, want %s", m.Config.ModulePath, cfg.ModulePath)
+	}
+	if m.GossamerVersion == "" {
+		t.Error("Manifest does not record the gossamer version")
+	}
+	if m.Modified("go.mod", content) {
+		t.Error("Manifest hash for go.mod does not match the generated file")
+	}
+	if want := len(gen.GetFileList()) - 1 - len(buildOutputs); len(m.Files) != want {
+		t.Errorf("Manifest records %d files, want %d", len(m.Files), want)
+	}
 }
 
 func TestTemplateFileVsStaticFile(t *testing.T) {
@@ -325,3 +521,199 @@ func isKnownTemplateFile(path string) bool {
 	}
 	return false
 }
+
+func TestEnvFilesMatchConfig(t *testing.T) {
+	for _, db := range config.SupportedDatabases {
+		t.Run(db, func(t *testing.T) {
+			cfg := &config.ProjectConfig{Name: "my-app", DatabaseType: db, IncludeAPI: true, IncludeTracing: true}
+			cfg.ApplyDefaults()
+
+			render := func(path string) string {
+				t.Helper()
+				content, err := fs.ReadFile(GetTemplatesFS(), path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				text, err := renderTemplate(path, string(content), cfg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return text
+			}
+
+			configGo := render("internal/infrastructure/config/config.gotmpl")
+			if !strings.Contains(configGo, `EnvPrefix = "MY_APP_"`) {
+				t.Error("Expected the config package to use the MY_APP_ prefix")
+			}
+
+			// Every variable in the env files is one config.Load reads
+			for _, path := range []string{"base/env_template.gotmpl", "base/env_dev.gotmpl"} {
+				for _, line := range strings.Split(render(path), "\n") {
+					if line == "" || strings.HasPrefix(line, "#") {
+						continue
+					}
+					name, _, _ := strings.Cut(line, "=")
+					key, ok := strings.CutPrefix(name, "MY_APP_")
+					if !ok {
+						t.Errorf("%s: variable %s is missing the MY_APP_ prefix", path, name)
+						continue
+					}
+					if !strings.Contains(configGo, `"`+key+`"`) {
+						t.Errorf("%s: variable %s is not read by config.Load", path, name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestComposeFilesMatchProject(t *testing.T) {
+	for _, db := range []string{"postgresql", "mysql"} {
+		t.Run(db, func(t *testing.T) {
+			cfg := &config.ProjectConfig{Name: "my-app", DatabaseType: db}
+			cfg.ApplyDefaults()
+
+			render := func(path string) string {
+				t.Helper()
+				content, err := fs.ReadFile(GetTemplatesFS(), path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				text, err := renderTemplate(path, string(content), cfg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return text
+			}
+
+			envDev := render("base/env_dev.gotmpl")
+			variable := regexp.MustCompile(`\$\{(\w+)\}`)
+			for _, path := range []string{"base/compose.gotmpl", "base/compose.override.gotmpl", "base/compose.production.gotmpl"} {
+				compose := render(path)
+				if !strings.Contains(compose, "my-app-db:") {
+					t.Errorf("%s: expected the database service to be named my-app-db", path)
+				}
+				// Every variable compose interpolates is set in config/.env
+				for _, match := range variable.FindAllStringSubmatch(compose, -1) {
+					if !strings.Contains(envDev, "\n"+match[1]+"=") {
+						t.Errorf("%s: variable %s is not set in config/.env", path, match[1])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratorVendorsHTMX(t *testing.T) {
+	cfg := &config.ProjectConfig{
+		Name:         "vendored",
+		ModulePath:   "github.com/test/vendored",
+		IncludeHTMX:  true,
+		DatabaseType: "postgresql",
+	}
+	projectDir := generateTestProject(t, cfg)
+
+	// The base layout loads them from the app itself
+	for _, name := range []string{"htmx.min.js", "response-targets.js"} {
+		path := filepath.Join(projectDir, "static/js/vendor", name)
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("static/js/vendor/%s missing from a project with HTMX; run `just vendor-js`", name)
+		}
+	}
+}
+
+// hidingFS is a filesystem without the hidden file.
+type hidingFS struct {
+	fs.FS
+	hidden string
+}
+
+func (h hidingFS) Open(name string) (fs.File, error) {
+	if name == h.hidden {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return h.FS.Open(name)
+}
+
+func TestGeneratorFailsWithoutVendoredHTMX(t *testing.T) {
+	cfg := &config.ProjectConfig{
+		Name:         "unvendored",
+		ModulePath:   "github.com/test/unvendored",
+		IncludeHTMX:  true,
+		DatabaseType: "postgresql",
+	}
+	t.Chdir(t.TempDir())
+
+	gen := New(cfg)
+	gen.SetTemplatesFS(hidingFS{FS: GetTemplatesFS(), hidden: "static/js/vendor/htmx.min.js"})
+	err := gen.Generate()
+	if err == nil || !strings.Contains(err.Error(), "htmx.min.js") {
+		t.Errorf("Generate() error = %v, want one naming htmx.min.js", err)
+	}
+}
+
+func TestWebTemplatesLoadNoRemoteScripts(t *testing.T) {
+	cfg := &config.ProjectConfig{Name: "my-app", IncludeHTMX: true}
+	cfg.ApplyDefaults()
+
+	tag := regexp.MustCompile(`<(script|link)\s[^>]*>`)
+	err := fs.WalkDir(GetTemplatesFS(), "web-templates", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(GetTemplatesFS(), path)
+		if err != nil {
+			return err
+		}
+		text, err := renderTemplate(path, string(content), cfg)
+		if err != nil {
+			return err
+		}
+
+		// Scripts and stylesheets are served by the app, with their
+		// integrity hash
+		for _, element := range tag.FindAllString(text, -1) {
+			if strings.Contains(element, `="http`) {
+				t.Errorf("%s: %s loads a remote file", path, element)
+			}
+			if !strings.Contains(element, "integrity=") {
+				t.Errorf("%s: %s has no integrity hash", path, element)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebTemplatesAllowedByContentSecurityPolicy(t *testing.T) {
+	handler := regexp.MustCompile(`\son[a-z]+=`)
+	script := regexp.MustCompile(`<script[\s>][^>]*`)
+	for _, dir := range []string{"web-templates", "resource/web-templates"} {
+		err := fs.WalkDir(GetTemplatesFS(), dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			content, err := fs.ReadFile(GetTemplatesFS(), path)
+			if err != nil {
+				return err
+			}
+
+			// The policy blocks inline event handlers, and scripts without
+			// the nonce of the request
+			if m := handler.FindString(string(content)); m != "" {
+				t.Errorf("%s: has an inline event handler,%s", path, m)
+			}
+			for _, element := range script.FindAllString(string(content), -1) {
+				if !strings.Contains(element, "nonce=") {
+					t.Errorf("%s: %s has no nonce", path, element)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"flag"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cumulusware/gossamer/internal/config"
+)
+
+// integrationEnv is the environment variable that opts in to the
+// integration tests, which take several minutes and need modCacheDir.
+const integrationEnv = "GOSSAMER_INTEGRATION"
+
+// modCacheDir is the module cache used to build the generated projects. It
+// is filled once with `just modcache`, after which the integration tests
+// run offline.
+const modCacheDir = "testdata/modcache"
+
+var download = flag.Bool("download", false, "download missing modules into "+modCacheDir)
+
+// featureCombinations returns a configuration for every supported database
+// combined with every combination of the boolean feature flags of
+// ProjectConfig. The projects share their module path, and goEnv builds
+// them with -trimpath, so the packages and tests they have in common are
+// built and run once, and cached across runs.
+func featureCombinations() []config.ProjectConfig {
+	var flags []string
+	configType := reflect.TypeOf(config.ProjectConfig{})
+	for i := 0; i < configType.NumField(); i++ {
+		if configType.Field(i).Type.Kind() == reflect.Bool {
+			flags = append(flags, configType.Field(i).Name)
+		}
+	}
+
+	var combinations []config.ProjectConfig
+	for _, database := range config.SupportedDatabases {
+		all := 1<<len(flags) - 1
+		for mask := 0; mask <= all; mask++ {
+			cfg := config.ProjectConfig{DatabaseType: database}
+			nameParts := []string{"app", database}
+			for i, name := range flags {
+				if mask&(1<<i) != 0 {
+					reflect.ValueOf(&cfg).Elem().FieldByName(name).SetBool(true)
+					nameParts = append(nameParts, strings.ToLower(strings.TrimPrefix(name, "Include")))
+				}
+			}
+
+			cfg.Name = strings.Join(nameParts, "-")
+			cfg.ModulePath = "example.com/app"
+			cfg.ApplyDefaults()
+			combinations = append(combinations, cfg)
+		}
+	}
+
+	return combinations
+}
+
+// goEnv returns the environment for running the go command on a generated
+// project using only the modules in the test module cache.
+func goEnv(t *testing.T) []string {
+	t.Helper()
+
+	cache, err := filepath.Abs(modCacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxy := "off"
+	if *download {
+		proxy = "https://proxy.golang.org,direct"
+	} else if _, err := os.Stat(cache); err != nil {
+		t.Fatalf("Module cache %s not found; run `just modcache` to fill it", modCacheDir)
+	}
+
+	return append(os.Environ(),
+		"GOMODCACHE="+cache,
+		"GOPROXY="+proxy,
+		"GOSUMDB=off",
+		"GOFLAGS=-mod=mod -modcacherw -trimpath",
+		"GOTOOLCHAIN=local",
+		"GOWORK=off",
+	)
+}
+
+// backdate sets the modification time of the files in dir an hour back:
+// the go command caches nothing built from files modified in the last few
+// seconds.
+func backdate(t *testing.T, dir string) {
+	t.Helper()
+
+	past := time.Now().Add(-time.Hour)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, past, past)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIntegrationGeneratedProjectBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	if os.Getenv(integrationEnv) == "" && !*download {
+		t.Skipf("Skipping integration test; set %s=1 to run it", integrationEnv)
+	}
+
+	env := goEnv(t)
+
+	for _, cfg := range featureCombinations() {
+		t.Run(cfg.Name, func(t *testing.T) {
+			// Generating changes the working directory, so only the go
+			// commands run in parallel
+			projectDir := generateTestProject(t, &cfg)
+
+			res, err := ParseResource("Product", []string{"name:string", "price:decimal", "published:bool"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			gen, err := NewResourceGenerator(projectDir, res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := gen.Generate(false); err != nil {
+				t.Fatalf("Failed to generate resource: %v", err)
+			}
+			t.Parallel()
+
+			// go test builds every package, tested or not, and vets it with
+			// all the checks of go vet; only the server binary is not
+			// linked, which takes as long as the rest once cached
+			backdate(t, projectDir)
+			cmd := exec.Command("go", "test", "-vet=all", "./...")
+			cmd.Dir = projectDir
+			cmd.Env = env
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("go test failed: %v\n%s", err, out)
+			}
+		})
+	}
+}
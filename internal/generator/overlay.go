@@ -0,0 +1,119 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// layerFS reads the regular files of upper in place of the files with the
+// same path in lower.
+type layerFS struct {
+	upper fs.FS
+	lower fs.FS
+}
+
+// Open opens the named file from the upper filesystem if it has a regular
+// file by that name and from the lower filesystem otherwise.
+func (l layerFS) Open(name string) (fs.File, error) {
+	if l.shadows(name) {
+		return l.upper.Open(name)
+	}
+	return l.lower.Open(name)
+}
+
+func (l layerFS) shadows(name string) bool {
+	info, err := fs.Stat(l.upper, name)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// OverlayFS layers a directory of templates over the templates of a
+// blueprint. A file in the directory shadows the template with the same
+// source path, e.g., base/readme.gotmpl or static/app.css.
+type OverlayFS struct {
+	Dir string
+	layerFS
+}
+
+// NewOverlayFS returns the base templates overlaid with the files in dir.
+// The directory is made absolute, so it can be recorded in the manifest.
+func NewOverlayFS(dir string, base fs.FS) (*OverlayFS, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve templates directory: %w", err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("templates directory %s is not a directory", dir)
+	}
+
+	return &OverlayFS{
+		Dir:     dir,
+		layerFS: layerFS{upper: os.DirFS(dir), lower: base},
+	}, nil
+}
+
+// UserTemplatesDir returns the default templates overlay directory of the
+// current user, e.g., ~/.config/gossamer/templates on Linux.
+func UserTemplatesDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gossamer", "templates"), nil
+}
+
+// Shadows reports whether the named file is read from the overlay
+// directory.
+func (o *OverlayFS) Shadows(name string) bool {
+	return o.shadows(name)
+}
+
+// Unused returns the files in the overlay directory that do not shadow any
+// base template, which usually means their path is misspelled.
+func (o *OverlayFS) Unused() ([]string, error) {
+	var unused []string
+	err := fs.WalkDir(o.upper, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if _, err := fs.Stat(o.lower, path); errors.Is(err, fs.ErrNotExist) {
+			unused = append(unused, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	return unused, nil
+}
+
+// overlayDir returns the overlay directory of a templates filesystem, or an
+// empty string if it has none.
+func overlayDir(templatesFS fs.FS) string {
+	if o, ok := templatesFS.(*OverlayFS); ok {
+		return o.Dir
+	}
+	return ""
+}
+
+// fromOverlay reports whether the file at the source path of a templates
+// filesystem is read from an overlay directory.
+func fromOverlay(templatesFS fs.FS, sourcePath string) bool {
+	o, ok := templatesFS.(*OverlayFS)
+	return ok && o.Shadows(sourcePath)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cumulusware/gossamer/internal/config"
+	"github.com/cumulusware/gossamer/internal/manifest"
+)
+
+// writeOverlay creates a templates overlay directory containing files.
+func writeOverlay(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for path, content := range files {
+		fullPath := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestOverlayFS(t *testing.T) {
+	dir := writeOverlay(t, map[string]string{
+		"base/readme.gotmpl": "# {{.Name}} by ACME\n",
+		"static/app.css":     "body { color: red; }\n",
+		"base/reedme.gotmpl": "misspelled\n",
+	})
+
+	overlay, err := NewOverlayFS(dir, GetTemplatesFS())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := fs.ReadFile(overlay, "static/app.css")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "body { color: red; }\n" {
+		t.Errorf("Expected the overlay app.css, got %q", data)
+	}
+
+	embedded, _ := fs.ReadFile(GetTemplatesFS(), "base/justfile.gotmpl")
+	data, err = fs.ReadFile(overlay, "base/justfile.gotmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(embedded) {
+		t.Error("Expected the embedded Justfile template when the overlay has none")
+	}
+
+	if !overlay.Shadows("base/readme.gotmpl") || overlay.Shadows("base/justfile.gotmpl") {
+		t.Error("Shadows should only report the files in the overlay directory")
+	}
+	if overlay.Shadows("base") {
+		t.Error("Directories should not shadow the embedded templates")
+	}
+
+	unused, err := overlay.Unused()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unused, []string{"base/reedme.gotmpl"}) {
+		t.Errorf("Expected only the misspelled template to be unused, got %v", unused)
+	}
+}
+
+func TestNewOverlayFSMissingDir(t *testing.T) {
+	if _, err := NewOverlayFS(filepath.Join(t.TempDir(), "missing"), GetTemplatesFS()); err == nil {
+		t.Error("Expected an error for a missing templates directory")
+	}
+}
+
+func TestGenerateWithOverlay(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping file creation test in short mode")
+	}
+
+	overlay, err := NewOverlayFS(writeOverlay(t, map[string]string{
+		"base/readme.gotmpl": "# {{.Name}} by ACME\n",
+	}), GetTemplatesFS())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.ProjectConfig{
+		Name:         "test-overlay",
+		ModulePath:   "github.com/test/test-overlay",
+		DatabaseType: "postgresql",
+	}
+	gen := New(cfg)
+	gen.SetTemplatesFS(overlay)
+
+	if files := gen.OverlayFiles(); !reflect.DeepEqual(files, []string{"README.md"}) {
+		t.Errorf("Expected README.md to come from the overlay, got %v", files)
+	}
+
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Failed to generate project: %v", err)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(tempDir, cfg.Name, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(readme) != "# test-overlay by ACME\n" {
+		t.Errorf("Expected the README rendered from the overlay, got %q", readme)
+	}
+
+	m, err := manifest.Load(filepath.Join(tempDir, cfg.Name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Templates != overlay.Dir {
+		t.Errorf("Expected the manifest to record the templates in %s, got %q", overlay.Dir, m.Templates)
+	}
+
+	justfile, err := os.ReadFile(filepath.Join(tempDir, cfg.Name, "Justfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(justfile), "test-overlay") {
+		t.Error("Expected the Justfile rendered from the embedded template")
+	}
+}
+
+func TestUpgradeRecordsOverlay(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping file creation test in short mode")
+	}
+
+	cfg := &config.ProjectConfig{
+		Name:         "test-upgrade-overlay",
+		ModulePath:   "github.com/test/test-upgrade-overlay",
+		DatabaseType: "postgresql",
+	}
+	projectDir := generateTestProject(t, cfg)
+
+	overlay, err := NewOverlayFS(writeOverlay(t, map[string]string{
+		"base/readme.gotmpl": "# {{.Name}} by ACME\n",
+	}), GetTemplatesFS())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upgrader, err := NewUpgrader(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upgrader.SetTemplatesFS(overlay)
+	if _, err := upgrader.Apply(); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Templates != overlay.Dir {
+		t.Errorf("Expected the manifest to record the templates in %s, got %q", overlay.Dir, m.Templates)
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// FieldType describes how a resource field type maps onto Go, SQL and HTML.
+type FieldType struct {
+	GoType     string // Go type used in the domain entity
+	SQLType    string // PostgreSQL column type
+	SQLiteType string // SQLite column type
+	MySQLType  string // MySQL column type
+	InputType  string // HTML input type used in the edit form
+	Parser     string // How form values are parsed: string, int, int64, float, bool, time, date, uuid
+}
+
+// FieldTypes lists the field types accepted by `generate resource`.
+var FieldTypes = map[string]FieldType{
+	"string":   {GoType: "string", SQLType: "VARCHAR(255)", SQLiteType: "TEXT", MySQLType: "VARCHAR(255)", InputType: "text", Parser: "string"},
+	"text":     {GoType: "string", SQLType: "TEXT", SQLiteType: "TEXT", MySQLType: "TEXT", InputType: "textarea", Parser: "string"},
+	"int":      {GoType: "int", SQLType: "INTEGER", SQLiteType: "INTEGER", MySQLType: "INT", InputType: "number", Parser: "int"},
+	"int64":    {GoType: "int64", SQLType: "BIGINT", SQLiteType: "INTEGER", MySQLType: "BIGINT", InputType: "number", Parser: "int64"},
+	"float":    {GoType: "float64", SQLType: "DOUBLE PRECISION", SQLiteType: "REAL", MySQLType: "DOUBLE", InputType: "number", Parser: "float"},
+	"decimal":  {GoType: "float64", SQLType: "NUMERIC(12, 2)", SQLiteType: "REAL", MySQLType: "DECIMAL(12, 2)", InputType: "number", Parser: "float"},
+	"bool":     {GoType: "bool", SQLType: "BOOLEAN", SQLiteType: "BOOLEAN", MySQLType: "BOOLEAN", InputType: "checkbox", Parser: "bool"},
+	"time":     {GoType: "time.Time", SQLType: "TIMESTAMP WITH TIME ZONE", SQLiteType: "DATETIME", MySQLType: "DATETIME(6)", InputType: "datetime-local", Parser: "time"},
+	"date":     {GoType: "time.Time", SQLType: "DATE", SQLiteType: "DATE", MySQLType: "DATE", InputType: "date", Parser: "date"},
+	"uuid":     {GoType: "uuid.UUID", SQLType: "UUID", SQLiteType: "TEXT", MySQLType: "CHAR(36)", InputType: "text", Parser: "uuid"},
+	"datetime": {GoType: "time.Time", SQLType: "TIMESTAMP WITH TIME ZONE", SQLiteType: "DATETIME", MySQLType: "DATETIME(6)", InputType: "datetime-local", Parser: "time"},
+}
+
+// Field is a single attribute of a generated resource.
+type Field struct {
+	FieldType
+	Name   string // Go field name, e.g., "UnitPrice"
+	Column string // SQL column and form field name, e.g., "unit_price"
+	Label  string // Human readable label, e.g., "Unit price"
+	Text   string // Lower case label for messages, e.g., "unit price"
+	Type   string // Type as given on the command line, e.g., "decimal"
+}
+
+// Resource describes a CRUD resource to be generated inside an existing
+// project.
+type Resource struct {
+	Name        string // Exported Go name, e.g., "Product"
+	Plural      string // Exported plural name, e.g., "Products"
+	Var         string // Unexported Go name, e.g., "product"
+	Package     string // Domain package name, e.g., "product"
+	Snake       string // Snake case name used for file names, e.g., "product"
+	Table       string // SQL table name, e.g., "products"
+	URLPath     string // URL path segment, e.g., "products"
+	Label       string // Human readable singular, e.g., "Product"
+	Text        string // Lower case singular for messages, e.g., "product"
+	PluralLabel string // Human readable plural, e.g., "Products"
+	PluralText  string // Lower case plural for messages, e.g., "products"
+	Fields      []Field
+}
+
+var identRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// builtinNames are the snake case names of the parts a project, or one of
+// its features, already has. A resource by one of these names would
+// overwrite their packages, handlers, or tables.
+var builtinNames = map[string]bool{
+	"account":         true,
+	"account_token":   true,
+	"api_token":       true,
+	"auth":            true,
+	"authz":           true,
+	"dev_mail":        true,
+	"home":            true,
+	"job":             true,
+	"permission":      true,
+	"role":            true,
+	"role_permission": true,
+	"session":         true,
+	"token":           true,
+	"user":            true,
+	"user_role":       true,
+}
+
+// sqlReservedWords are words reserved, or given a meaning of their own, by
+// PostgreSQL, MySQL, or SQLite, which cannot be used unquoted as table or
+// column names.
+var sqlReservedWords = map[string]bool{
+	"all": true, "alter": true, "analyze": true, "and": true, "any": true,
+	"as": true, "asc": true, "between": true, "both": true, "by": true,
+	"case": true, "cast": true, "change": true, "check": true, "collate": true,
+	"column": true, "condition": true, "constraint": true, "create": true,
+	"cross": true, "current": true, "current_date": true,
+	"current_time": true, "current_timestamp": true, "current_user": true,
+	"database": true, "default": true, "delete": true, "desc": true,
+	"describe": true, "distinct": true, "div": true, "do": true, "drop": true,
+	"else": true, "end": true, "except": true, "exists": true,
+	"explain": true, "false": true, "fetch": true, "for": true,
+	"foreign": true, "from": true, "full": true, "grant": true,
+	"group": true, "groups": true, "having": true, "if": true, "in": true,
+	"index": true, "inner": true, "insert": true, "intersect": true,
+	"interval": true, "into": true, "is": true, "join": true, "key": true,
+	"keys": true, "leading": true, "left": true, "like": true, "limit": true,
+	"lock": true, "match": true, "mod": true, "natural": true, "not": true,
+	"null": true, "offset": true, "on": true, "option": true, "or": true,
+	"order": true, "outer": true, "over": true, "primary": true,
+	"range": true, "rank": true, "read": true, "references": true,
+	"release": true, "rename": true, "replace": true, "return": true,
+	"revoke": true, "right": true, "row": true, "rows": true,
+	"schema": true, "select": true, "session_user": true, "set": true,
+	"show": true, "signal": true, "some": true, "table": true, "then": true,
+	"to": true, "trailing": true, "trigger": true, "true": true,
+	"type": true, "union": true, "unique": true, "update": true,
+	"usage": true, "use": true, "user": true, "using": true,
+	"values": true, "when": true, "where": true, "window": true,
+	"with": true, "write": true,
+}
+
+// ParseResource parses a resource name and its field specifications, given
+// as name:type pairs (e.g., "price:decimal").
+func ParseResource(name string, specs []string) (*Resource, error) {
+	if !identRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid resource name %q", name)
+	}
+
+	words := splitWords(name)
+	res := &Resource{
+		Name:    camelCase(words, true),
+		Var:     camelCase(words, false),
+		Package: strings.ToLower(strings.Join(words, "")),
+		Snake:   strings.Join(words, "_"),
+		Label:   sentenceCase(words),
+		Text:    strings.Join(words, " "),
+	}
+	pluralWords := append(append([]string{}, words[:len(words)-1]...), pluralize(words[len(words)-1]))
+	res.Plural = camelCase(pluralWords, true)
+	res.Table = strings.Join(pluralWords, "_")
+	res.URLPath = strings.Join(pluralWords, "-")
+	res.PluralLabel = sentenceCase(pluralWords)
+	res.PluralText = strings.Join(pluralWords, " ")
+
+	if token.IsKeyword(res.Var) || token.IsKeyword(res.Package) {
+		return nil, fmt.Errorf("resource name %q is a Go keyword", name)
+	}
+
+	if builtinNames[res.Snake] || builtinNames[res.Package] {
+		return nil, fmt.Errorf("resource name %q is taken by a built-in part of the project", name)
+	}
+	if sqlReservedWords[res.Table] {
+		return nil, fmt.Errorf("resource name %q makes the table name %q, an SQL reserved word", name, res.Table)
+	}
+
+	if res.Plural == res.Name {
+		return nil, fmt.Errorf("resource name %q must have a distinct plural form", name)
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("resource %s needs at least one field (e.g., name:string)", res.Name)
+	}
+
+	seen := map[string]bool{"id": true, "created_at": true, "updated_at": true}
+	for _, spec := range specs {
+		fieldName, typeName, ok := strings.Cut(spec, ":")
+		if !ok {
+			typeName = "string"
+		}
+		if !identRegexp.MatchString(fieldName) {
+			return nil, fmt.Errorf("invalid field name %q", fieldName)
+		}
+
+		fieldType, ok := FieldTypes[strings.ToLower(typeName)]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q for field %s", typeName, fieldName)
+		}
+
+		fieldWords := splitWords(fieldName)
+		field := Field{
+			FieldType: fieldType,
+			Name:      camelCase(fieldWords, true),
+			Column:    strings.Join(fieldWords, "_"),
+			Label:     sentenceCase(fieldWords),
+			Text:      strings.Join(fieldWords, " "),
+			Type:      strings.ToLower(typeName),
+		}
+		if sqlReservedWords[field.Column] {
+			return nil, fmt.Errorf("field name %q is an SQL reserved word", fieldName)
+		}
+		if seen[field.Column] {
+			return nil, fmt.Errorf("duplicate or reserved field %q", fieldName)
+		}
+		seen[field.Column] = true
+
+		res.Fields = append(res.Fields, field)
+	}
+
+	return res, nil
+}
+
+// Columns returns the comma separated SQL columns for the resource's fields.
+func (r *Resource) Columns() string {
+	cols := make([]string, len(r.Fields))
+	for i, f := range r.Fields {
+		cols[i] = f.Column
+	}
+	return strings.Join(cols, ", ")
+}
+
+// Placeholders returns the PostgreSQL placeholders for the resource's
+// fields, numbered from start.
+func (r *Resource) Placeholders(start int) string {
+	ph := make([]string, len(r.Fields))
+	for i := range r.Fields {
+		ph[i] = fmt.Sprintf("$%d", start+i)
+	}
+	return strings.Join(ph, ", ")
+}
+
+// NextParam returns the PostgreSQL placeholder numbered offset past the
+// number of fields. With the field placeholders starting at $2, NextParam 2
+// is the first placeholder after them and NextParam 3 the second.
+func (r *Resource) NextParam(offset int) string {
+	return fmt.Sprintf("$%d", len(r.Fields)+offset)
+}
+
+// Assignments returns the SQL SET clause for the resource's fields, with
+// placeholders numbered from start.
+func (r *Resource) Assignments(start int) string {
+	set := make([]string, len(r.Fields))
+	for i, f := range r.Fields {
+		set[i] = fmt.Sprintf("%s = $%d", f.Column, start+i)
+	}
+	return strings.Join(set, ", ")
+}
+
+// Binds returns the "?" placeholders for the resource's fields, as used by
+// database/sql drivers other than PostgreSQL.
+func (r *Resource) Binds() string {
+	return strings.TrimSuffix(strings.Repeat("?, ", len(r.Fields)), ", ")
+}
+
+// BindAssignments returns the SQL SET clause for the resource's fields
+// using "?" placeholders.
+func (r *Resource) BindAssignments() string {
+	set := make([]string, len(r.Fields))
+	for i, f := range r.Fields {
+		set[i] = f.Column + " = ?"
+	}
+	return strings.Join(set, ", ")
+}
+
+// ColumnCount returns the number of columns in the generated list table,
+// being one per field plus the actions column.
+func (r *Resource) ColumnCount() int {
+	return len(r.Fields) + 1
+}
+
+// HasParser reports whether any field is parsed with one of the given form
+// parsers, which decides the imports needed by the generated handlers.
+func (r *Resource) HasParser(parsers ...string) bool {
+	for _, f := range r.Fields {
+		for _, p := range parsers {
+			if f.Parser == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitWords splits an identifier in snake_case, kebab-case, or CamelCase
+// into its lower case words.
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(s)
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			flush()
+		case unicode.IsUpper(r) && i > 0 &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+
+	return words
+}
+
+// commonInitialisms are kept upper case in Go names, following golint.
+var commonInitialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "id": true, "ip": true,
+	"json": true, "sku": true, "sql": true, "url": true, "uuid": true,
+}
+
+func camelCase(words []string, exported bool) string {
+	var b strings.Builder
+	for i, w := range words {
+		if i == 0 && !exported {
+			b.WriteString(w)
+			continue
+		}
+		if commonInitialisms[w] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+func sentenceCase(words []string) string {
+	s := strings.Join(words, " ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
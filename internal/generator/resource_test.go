@@ -0,0 +1,314 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cumulusware/gossamer/internal/config"
+)
+
+func TestParseResource(t *testing.T) {
+	res, err := ParseResource("OrderItem", []string{"name:string", "unit_price:decimal", "published:bool"})
+	if err != nil {
+		t.Fatalf("ParseResource() error = %v", err)
+	}
+
+	checks := map[string][2]string{
+		"Name":       {res.Name, "OrderItem"},
+		"Plural":     {res.Plural, "OrderItems"},
+		"Var":        {res.Var, "orderItem"},
+		"Package":    {res.Package, "orderitem"},
+		"Snake":      {res.Snake, "order_item"},
+		"Table":      {res.Table, "order_items"},
+		"URLPath":    {res.URLPath, "order-items"},
+		"PluralText": {res.PluralText, "order items"},
+	}
+	for name, c := range checks {
+		if c[0] != c[1] {
+			t.Errorf("Expected %s to be '%s', got '%s'", name, c[1], c[0])
+		}
+	}
+
+	if len(res.Fields) != 3 {
+		t.Fatalf("Expected 3 fields, got %d", len(res.Fields))
+	}
+	price := res.Fields[1]
+	if price.Name != "UnitPrice" || price.Column != "unit_price" || price.GoType != "float64" {
+		t.Errorf("Unexpected price field: %+v", price)
+	}
+}
+
+func TestParseResourceErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		fields   []string
+	}{
+		{"no fields", "Product", nil},
+		{"invalid name", "1Product", []string{"name:string"}},
+		{"keyword", "Type", []string{"name:string"}},
+		{"unknown type", "Product", []string{"name:varchar"}},
+		{"reserved field", "Product", []string{"id:uuid"}},
+		{"duplicate field", "Product", []string{"name:string", "Name:text"}},
+		{"built-in domain", "User", []string{"name:string"}},
+		{"built-in table", "Role", []string{"name:string"}},
+		{"built-in handler", "Token", []string{"name:string"}},
+		{"reserved table", "Group", []string{"name:string"}},
+		{"reserved word field", "Product", []string{"order:int"}},
+		{"reserved word field in camel case", "Product", []string{"currentDate:date"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseResource(tt.resource, tt.fields); err == nil {
+				t.Errorf("ParseResource(%q, %v) expected error", tt.resource, tt.fields)
+			}
+		})
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	tests := map[string]string{
+		"product":  "products",
+		"category": "categories",
+		"day":      "days",
+		"box":      "boxes",
+		"status":   "statuses",
+		"batch":    "batches",
+	}
+	for word, want := range tests {
+		if got := pluralize(word); got != want {
+			t.Errorf("pluralize(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestResourcePlaceholders(t *testing.T) {
+	res, err := ParseResource("Product", []string{"name", "price:decimal"})
+	if err != nil {
+		t.Fatalf("ParseResource() error = %v", err)
+	}
+
+	if got := res.Columns(); got != "name, price" {
+		t.Errorf("Columns() = %q", got)
+	}
+	if got := res.Placeholders(2); got != "$2, $3" {
+		t.Errorf("Placeholders(2) = %q", got)
+	}
+	if got := res.NextParam(2); got != "$4" {
+		t.Errorf("NextParam(2) = %q", got)
+	}
+	if got := res.Assignments(2); got != "name = $2, price = $3" {
+		t.Errorf("Assignments(2) = %q", got)
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	src := `package web
+
+import (
+	"net/http"
+
+	"example.com/app/internal/domain/auth"
+)
+
+type Handlers struct {
+	AuthService *auth.Service
+	// gossamer:scaffold:services
+}
+`
+	p := patch{
+		Marker:  markerServices,
+		Lines:   []string{"ProductService *product.Service"},
+		Imports: []string{"example.com/app/internal/domain/product"},
+	}
+
+	out, err := applyPatch(src, p)
+	if err != nil {
+		t.Fatalf("applyPatch() error = %v", err)
+	}
+
+	if !strings.Contains(out, "\tProductService *product.Service\n\t// gossamer:scaffold:services") {
+		t.Errorf("Field not inserted above marker:\n%s", out)
+	}
+	if !strings.Contains(out, `"example.com/app/internal/domain/auth"
+	"example.com/app/internal/domain/product"`) {
+		t.Errorf("Import not added to the project import group:\n%s", out)
+	}
+
+	// Applying the same patch again must not duplicate anything
+	again, err := applyPatch(out, p)
+	if err != nil {
+		t.Fatalf("applyPatch() second run error = %v", err)
+	}
+	if again != out {
+		t.Errorf("applyPatch() is not idempotent:\n%s", again)
+	}
+
+	if _, err := applyPatch("package web\n", p); err == nil {
+		t.Error("Expected error when the scaffold marker is missing")
+	}
+}
+
+func TestNextMigrationVersion(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"001_users.sql", "002_sessions.sql", "010_orders.sql", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	version, err := nextMigrationVersion(dir, now)
+	if err != nil {
+		t.Fatalf("nextMigrationVersion() error = %v", err)
+	}
+	if version != "20250304050607" {
+		t.Errorf("Expected version '20250304050607', got '%s'", version)
+	}
+
+	// A migration generated within the same second comes after the last
+	if err := os.WriteFile(filepath.Join(dir, version+"_create_products_table.sql"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	version, err = nextMigrationVersion(dir, now)
+	if err != nil {
+		t.Fatalf("nextMigrationVersion() error = %v", err)
+	}
+	if version != "20250304050608" {
+		t.Errorf("Expected version '20250304050608', got '%s'", version)
+	}
+}
+
+func TestMigrationVersion(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"001_create_users_table.sql", "007_create_products_table.sql", "20250101000000_create_orders_table.sql"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]string{
+		"products": "007", // generated again
+		"prices":   "20250304050607",
+	}
+	for table, want := range tests {
+		version, err := migrationVersion(dir, table, time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC))
+		if err != nil {
+			t.Fatalf("migrationVersion(%q) error = %v", table, err)
+		}
+		if version != want {
+			t.Errorf("migrationVersion(%q) = %q, want %q", table, version, want)
+		}
+	}
+}
+
+// Integration test that generates a project and then a resource inside it
+func TestResourceGeneratorFileCreation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping file creation test in short mode")
+	}
+
+	cfg := &config.ProjectConfig{
+		Name:         "test-resource",
+		ModulePath:   "github.com/test/test-resource",
+		IncludeAPI:   true,
+		DatabaseType: "postgresql",
+	}
+
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := New(cfg).Generate(); err != nil {
+		t.Fatalf("Failed to generate project: %v", err)
+	}
+
+	res, err := ParseResource("Product", []string{"name:string", "price:decimal", "published:bool"})
+	if err != nil {
+		t.Fatalf("ParseResource() error = %v", err)
+	}
+
+	projectPath := filepath.Join(tempDir, cfg.Name)
+	gen, err := NewResourceGenerator(projectPath, res)
+	if err != nil {
+		t.Fatalf("NewResourceGenerator() error = %v", err)
+	}
+
+	if err := gen.Generate(false); err != nil {
+		t.Fatalf("Failed to generate resource: %v", err)
+	}
+
+	expectedFiles := []string{
+		"internal/domain/product/entity.go",
+		"internal/adapters/repository/product_postgres.go",
+		"internal/adapters/handlers/api/product_handler.go",
+		"internal/infrastructure/web/templates/product_edit.gohtml",
+	}
+	for _, file := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(projectPath, file)); os.IsNotExist(err) {
+			t.Errorf("Resource file %s was not created", file)
+		}
+	}
+
+	router, err := os.ReadFile(filepath.Join(projectPath, "internal/infrastructure/web/router.go"))
+	if err != nil {
+		t.Fatalf("Failed to read router.go: %v", err)
+	}
+	for _, route := range []string{
+		`"GET /products", s.webHandlers.ListProducts`,
+		`"DELETE /products/{id}", middleware.RequireScope("products:write", s.apiHandlers.DeleteProduct)`,
+	} {
+		if !strings.Contains(string(router), route) {
+			t.Errorf("router.go does not register %s", route)
+		}
+	}
+
+	token, err := os.ReadFile(filepath.Join(projectPath, "internal/domain/auth/token.go"))
+	if err != nil {
+		t.Fatalf("Failed to read token.go: %v", err)
+	}
+	for _, scope := range []string{`"products:read",`, `"products:write",`} {
+		if !strings.Contains(string(token), scope) {
+			t.Errorf("token.go does not define the scope %s", scope)
+		}
+	}
+
+	// A second run without force must refuse to overwrite the resource
+	if err := gen.Generate(false); err == nil {
+		t.Error("Expected error when regenerating an existing resource without force")
+	}
+
+	// Generating it again with force, twice, overwrites its migration
+	for range 2 {
+		gen, err := NewResourceGenerator(projectPath, res)
+		if err != nil {
+			t.Fatalf("NewResourceGenerator() error = %v", err)
+		}
+		if err := gen.Generate(true); err != nil {
+			t.Fatalf("Failed to generate resource with force: %v", err)
+		}
+	}
+	migrations, err := filepath.Glob(filepath.Join(projectPath, migrationsDir, "*_create_products_table.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 1 {
+		t.Fatalf("Expected a single products migration after regenerating with force, got %v", migrations)
+	}
+	if version, _, _ := strings.Cut(filepath.Base(migrations[0]), "_"); len(version) != len(migrationTimeFormat) {
+		t.Errorf("Expected the products migration to be versioned by time, got %s", filepath.Base(migrations[0]))
+	}
+}
@@ -0,0 +1,524 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/cumulusware/gossamer/internal/manifest"
+)
+
+// Scaffold markers are comments in the generated project where gossamer
+// inserts code for resources generated later on.
+const (
+	markerServices  = "// gossamer:scaffold:services"
+	markerResources = "// gossamer:scaffold:resources"
+	markerWebRoutes = "// gossamer:scaffold:web-routes"
+	markerAPIRoutes = "// gossamer:scaffold:api-routes"
+	markerScopes    = "// gossamer:scaffold:scopes"
+)
+
+const migrationsDir = "internal/infrastructure/database/migrations"
+
+// ResourceData is the data passed to the resource templates.
+type ResourceData struct {
+	*Resource
+	ModulePath       string
+	IncludeAPI       bool
+	DatabaseType     string
+	MigrationVersion string
+}
+
+// ResourceGenerator adds a CRUD resource to an existing generated project.
+type ResourceGenerator struct {
+	projectDir  string
+	data        *ResourceData
+	templatesFS fs.FS
+}
+
+// patch describes code to insert at a scaffold marker in an existing file.
+type patch struct {
+	Path        string
+	Marker      string
+	Lines       []string
+	Imports     []string
+	Conditional string
+}
+
+// NewResourceGenerator prepares the generation of res inside the project
+// found at projectDir. The module path and enabled features are detected
+// from the project itself, and the database from its manifest.
+func NewResourceGenerator(projectDir string, res *Resource) (*ResourceGenerator, error) {
+	modulePath, err := readModulePath(filepath.Join(projectDir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := migrationVersion(filepath.Join(projectDir, migrationsDir), res.Table, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = os.Stat(filepath.Join(projectDir, "internal/adapters/handlers/api/handlers.go"))
+	includeAPI := err == nil
+
+	// Projects generated before the manifest existed only supported PostgreSQL
+	databaseType := "postgresql"
+	if manifest.Exists(projectDir) {
+		m, err := manifest.Load(projectDir)
+		if err != nil {
+			return nil, err
+		}
+		databaseType = m.Config.DatabaseType
+	}
+
+	return &ResourceGenerator{
+		projectDir: projectDir,
+		data: &ResourceData{
+			Resource:         res,
+			ModulePath:       modulePath,
+			IncludeAPI:       includeAPI,
+			DatabaseType:     databaseType,
+			MigrationVersion: version,
+		},
+		templatesFS: GetTemplatesFS(),
+	}, nil
+}
+
+// TemplatesFS returns the templates the resource is generated from.
+func (rg *ResourceGenerator) TemplatesFS() fs.FS {
+	return rg.templatesFS
+}
+
+// SetTemplatesFS replaces the embedded templates the resource is generated
+// from, e.g., with an OverlayFS.
+func (rg *ResourceGenerator) SetTemplatesFS(templatesFS fs.FS) {
+	rg.templatesFS = templatesFS
+}
+
+// OverlayFiles returns the files of the resource that would be generated
+// from an overlay directory rather than the embedded templates.
+func (rg *ResourceGenerator) OverlayFiles() ([]string, error) {
+	var result []string
+	for _, file := range GetResourceFiles() {
+		if !evalConditional(file.GetConditional(), rg.data) || !fromOverlay(rg.templatesFS, file.GetSourcePath()) {
+			continue
+		}
+		dest, err := renderTemplate("destination", file.GetDestinationPath(), rg.data)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dest)
+	}
+	return result, nil
+}
+
+// GetFileList returns the files that would be created followed by the
+// existing files that would be modified.
+func (rg *ResourceGenerator) GetFileList() ([]string, []string, error) {
+	var created, modified []string
+
+	for _, file := range GetResourceFiles() {
+		if !evalConditional(file.GetConditional(), rg.data) {
+			continue
+		}
+		dest, err := renderTemplate("destination", file.GetDestinationPath(), rg.data)
+		if err != nil {
+			return nil, nil, err
+		}
+		created = append(created, dest)
+	}
+
+	seen := map[string]bool{}
+	for _, p := range rg.patches() {
+		if evalConditional(p.Conditional, rg.data) && !seen[p.Path] {
+			seen[p.Path] = true
+			modified = append(modified, p.Path)
+		}
+	}
+
+	return created, modified, nil
+}
+
+// Generate writes the resource files and wires the resource into the
+// existing application. Existing files are only overwritten when force is
+// set.
+func (rg *ResourceGenerator) Generate(force bool) error {
+	type output struct {
+		path    string
+		content string
+		perm    fs.FileMode
+		overlay bool
+	}
+	var outputs []output
+
+	// Render everything before writing so a failure leaves the project as is
+	for _, file := range GetResourceFiles() {
+		if !evalConditional(file.GetConditional(), rg.data) {
+			continue
+		}
+
+		dest, err := renderTemplate("destination", file.GetDestinationPath(), rg.data)
+		if err != nil {
+			return err
+		}
+
+		fullPath := filepath.Join(rg.projectDir, dest)
+		if _, err := os.Stat(fullPath); err == nil && !force {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", dest)
+		}
+
+		content, err := renderFile(rg.templatesFS, file, rg.data)
+		if err != nil {
+			return fmt.Errorf("failed to generate content for %s: %w", dest, err)
+		}
+
+		outputs = append(outputs, output{
+			path:    dest,
+			content: content,
+			perm:    file.GetPermissions(),
+			overlay: fromOverlay(rg.templatesFS, file.GetSourcePath()),
+		})
+	}
+
+	patched := map[string]string{}
+	var patchOrder []string
+	for _, p := range rg.patches() {
+		if !evalConditional(p.Conditional, rg.data) {
+			continue
+		}
+
+		src, ok := patched[p.Path]
+		if !ok {
+			data, err := os.ReadFile(filepath.Join(rg.projectDir, p.Path))
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", p.Path, err)
+			}
+			src = string(data)
+			patchOrder = append(patchOrder, p.Path)
+		}
+
+		result, err := applyPatch(src, p)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", p.Path, err)
+		}
+		patched[p.Path] = result
+	}
+
+	for _, out := range outputs {
+		fullPath := filepath.Join(rg.projectDir, out.path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(fullPath), err)
+		}
+		if err := os.WriteFile(fullPath, []byte(out.content), out.perm); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", fullPath, err)
+		}
+		printFile(out.path, out.overlay)
+	}
+
+	for _, path := range patchOrder {
+		formatted, err := format.Source([]byte(patched[path]))
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %w", path, err)
+		}
+		if err := os.WriteFile(filepath.Join(rg.projectDir, path), formatted, 0644); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", path, err)
+		}
+		fmt.Printf("  ✏️  %s\n", color.YellowString(path))
+	}
+
+	// Record the new files in the project manifest, if the project has one
+	if manifest.Exists(rg.projectDir) {
+		m, err := manifest.Load(rg.projectDir)
+		if err != nil {
+			return err
+		}
+		for _, out := range outputs {
+			m.AddFile(out.path, []byte(out.content))
+		}
+		m.AddResource(rg.data.Name)
+		m.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+		if err := m.Save(rg.projectDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// patches returns the changes needed to wire the resource into the
+// application's handlers, app setup, and router.
+func (rg *ResourceGenerator) patches() []patch {
+	r := rg.data.Resource
+	domainImport := rg.data.ModulePath + "/internal/domain/" + r.Package
+	webRoutes := []string{
+		fmt.Sprintf(`webMux.HandleFunc("GET /%s", s.webHandlers.List%s)`, r.URLPath, r.Plural),
+		fmt.Sprintf(`webMux.HandleFunc("GET /%s/new", s.webHandlers.New%s)`, r.URLPath, r.Name),
+		fmt.Sprintf(`webMux.HandleFunc("POST /%s", s.webHandlers.Create%s)`, r.URLPath, r.Name),
+		fmt.Sprintf(`webMux.HandleFunc("GET /%s/{id}", s.webHandlers.Show%s)`, r.URLPath, r.Name),
+		fmt.Sprintf(`webMux.HandleFunc("GET /%s/{id}/edit", s.webHandlers.Edit%s)`, r.URLPath, r.Name),
+		fmt.Sprintf(`webMux.HandleFunc("POST /%s/{id}", s.webHandlers.Update%s)`, r.URLPath, r.Name),
+		fmt.Sprintf(`webMux.HandleFunc("POST /%s/{id}/delete", s.webHandlers.Delete%s)`, r.URLPath, r.Name),
+	}
+	readScope, writeScope := r.URLPath+":read", r.URLPath+":write"
+	apiRoutes := []string{
+		fmt.Sprintf(`apiMux.Handle("GET /%s", middleware.RequireScope(%q, s.apiHandlers.List%s))`, r.URLPath, readScope, r.Plural),
+		fmt.Sprintf(`apiMux.Handle("POST /%s", middleware.RequireScope(%q, s.apiHandlers.Create%s))`, r.URLPath, writeScope, r.Name),
+		fmt.Sprintf(`apiMux.Handle("GET /%s/{id}", middleware.RequireScope(%q, s.apiHandlers.Get%s))`, r.URLPath, readScope, r.Name),
+		fmt.Sprintf(`apiMux.Handle("PUT /%s/{id}", middleware.RequireScope(%q, s.apiHandlers.Update%s))`, r.URLPath, writeScope, r.Name),
+		fmt.Sprintf(`apiMux.Handle("DELETE /%s/{id}", middleware.RequireScope(%q, s.apiHandlers.Delete%s))`, r.URLPath, writeScope, r.Name),
+	}
+	wiring := []string{
+		fmt.Sprintf("%sRepo := repository.New%s%s(db)", r.Var, r.Name, repositorySuffix(rg.data.DatabaseType)),
+		fmt.Sprintf("%sService := %s.NewService(%sRepo)", r.Var, r.Package, r.Var),
+		fmt.Sprintf("webHandlers.%sService = %sService", r.Name, r.Var),
+	}
+	if rg.data.IncludeAPI {
+		wiring = append(wiring, fmt.Sprintf("apiHandlers.%sService = %sService", r.Name, r.Var))
+	}
+	service := fmt.Sprintf("%sService *%s.Service", r.Name, r.Package)
+
+	return []patch{
+		{
+			Path:    "internal/adapters/handlers/web/handlers.go",
+			Marker:  markerServices,
+			Lines:   []string{service},
+			Imports: []string{domainImport},
+		},
+		{
+			Path:        "internal/adapters/handlers/api/handlers.go",
+			Marker:      markerServices,
+			Lines:       []string{service},
+			Imports:     []string{domainImport},
+			Conditional: "IncludeAPI",
+		},
+		{
+			Path:    "internal/app/app.go",
+			Marker:  markerResources,
+			Lines:   wiring,
+			Imports: []string{domainImport},
+		},
+		{
+			Path:   "internal/infrastructure/web/router.go",
+			Marker: markerWebRoutes,
+			Lines:  webRoutes,
+		},
+		{
+			Path:        "internal/infrastructure/web/router.go",
+			Marker:      markerAPIRoutes,
+			Lines:       apiRoutes,
+			Conditional: "IncludeAPI",
+		},
+		{
+			Path:        "internal/domain/auth/token.go",
+			Marker:      markerScopes,
+			Lines:       []string{fmt.Sprintf("%q,", readScope), fmt.Sprintf("%q,", writeScope)},
+			Conditional: "IncludeAPI",
+		},
+	}
+}
+
+// applyPatch inserts the patch lines just above its marker, using the
+// marker's indentation, and adds any missing imports. Lines that are
+// already present are not inserted again.
+func applyPatch(src string, p patch) (string, error) {
+	lines := strings.Split(src, "\n")
+
+	markerIdx := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == p.Marker {
+			markerIdx = i
+			break
+		}
+	}
+	if markerIdx < 0 {
+		return "", fmt.Errorf(
+			"scaffold marker %q not found; add the following manually:\n\t%s",
+			p.Marker,
+			strings.Join(p.Lines, "\n\t"),
+		)
+	}
+
+	indent := lines[markerIdx][:len(lines[markerIdx])-len(strings.TrimLeft(lines[markerIdx], " \t"))]
+	var insert []string
+	for _, l := range p.Lines {
+		if !strings.Contains(src, l) {
+			insert = append(insert, indent+l)
+		}
+	}
+
+	result := append([]string{}, lines[:markerIdx]...)
+	result = append(result, insert...)
+	result = append(result, lines[markerIdx:]...)
+	out := strings.Join(result, "\n")
+
+	for _, imp := range p.Imports {
+		var err error
+		if out, err = addImport(out, imp); err != nil {
+			return "", err
+		}
+	}
+
+	return out, nil
+}
+
+// addImport adds path to the import block of a Go source file, next to the
+// other imports sharing the longest common prefix so that it ends up in the
+// right import group once formatted.
+func addImport(src, path string) (string, error) {
+	quoted := strconv.Quote(path)
+	if strings.Contains(src, quoted) {
+		return src, nil
+	}
+
+	lines := strings.Split(src, "\n")
+	start, end := -1, -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "import (" {
+			start = i
+			continue
+		}
+		if start >= 0 && strings.TrimSpace(line) == ")" {
+			end = i
+			break
+		}
+	}
+	if start < 0 || end < 0 {
+		return "", fmt.Errorf("import block not found")
+	}
+
+	// Insert after the import sharing the longest prefix with path
+	insertAt, best := end, 0
+	for i := start + 1; i < end; i++ {
+		imp := strings.TrimSpace(lines[i])
+		if idx := strings.LastIndex(imp, `"`); idx > 0 {
+			imp = imp[strings.Index(imp, `"`)+1 : idx]
+		}
+		n := commonPrefixLen(imp, path)
+		if n >= best && n > 0 {
+			insertAt, best = i+1, n
+		}
+	}
+
+	result := append([]string{}, lines[:insertAt]...)
+	result = append(result, "\t"+quoted)
+	result = append(result, lines[insertAt:]...)
+	return strings.Join(result, "\n"), nil
+}
+
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// readModulePath returns the module path declared in a go.mod file.
+func readModulePath(goModPath string) (string, error) {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return "", fmt.Errorf("not a gossamer project (no go.mod found): %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`), nil
+		}
+	}
+
+	return "", fmt.Errorf("no module directive found in %s", goModPath)
+}
+
+var migrationVersionRegexp = regexp.MustCompile(`^(\d+)_(.*)\.sql$`)
+
+// migrationTimeFormat is the format of the versions of the migrations of
+// resources: the time they were generated, so they never take one of the
+// small numbers the templates give their own migrations, e.g., 004 to the
+// RBAC tables, whether or not the project has them yet.
+const migrationTimeFormat = "20060102150405"
+
+// migrationVersion returns the version of the migration creating table in
+// dir: that of the existing migration when the resource is generated again,
+// so it is overwritten rather than duplicated, or else a new version.
+func migrationVersion(dir, table string, now time.Time) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	for _, entry := range entries {
+		m := migrationVersionRegexp.FindStringSubmatch(entry.Name())
+		if m != nil && m[2] == "create_"+table+"_table" {
+			return m[1], nil
+		}
+	}
+	return nextMigrationVersion(dir, now)
+}
+
+// nextMigrationVersion returns the version of a migration added to dir at
+// now, which follows every migration in dir.
+func nextMigrationVersion(dir string, now time.Time) (string, error) {
+	highest, err := highestMigrationVersion(dir)
+	if err != nil {
+		return "", err
+	}
+	return migrationVersionAfter(highest, now), nil
+}
+
+// migrationVersionAfter returns the version of a migration added at now
+// following the migration versioned highest.
+func migrationVersionAfter(highest int64, now time.Time) string {
+	version := now.UTC().Format(migrationTimeFormat)
+	if n, _ := strconv.ParseInt(version, 10, 64); n <= highest {
+		return strconv.FormatInt(highest+1, 10)
+	}
+	return version
+}
+
+// highestMigrationVersion returns the highest version of the migrations in
+// dir, or 0 if there are none.
+func highestMigrationVersion(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var highest int64
+	for _, entry := range entries {
+		m := migrationVersionRegexp.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		if n, err := strconv.ParseInt(m[1], 10, 64); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return highest, nil
+}
+
+// repositorySuffix returns the suffix of the repository adapter names for
+// the database, e.g., NewUserPostgres or NewUserSQL.
+func repositorySuffix(databaseType string) string {
+	if databaseType == "postgresql" {
+		return "Postgres"
+	}
+	return "SQL"
+}
@@ -0,0 +1,12 @@
+/** @type {import('tailwindcss').Config} */
+module.exports = {
+  // The files scanned for the classes to include in static/css/app.css
+  content: [
+    './internal/infrastructure/web/templates/**/*.gohtml',
+    './static/js/app.js',
+  ],
+  theme: {
+    extend: {},
+  },
+  plugins: [],
+}
@@ -0,0 +1,52 @@
+-- +goose Up
+CREATE TABLE roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE permissions (
+    name VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE role_permissions (
+    role VARCHAR(50) NOT NULL,
+    permission VARCHAR(100) NOT NULL,
+    PRIMARY KEY (role, permission),
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE,
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission) REFERENCES permissions(name) ON DELETE CASCADE
+);
+
+CREATE TABLE user_roles (
+    user_id CHAR(36) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    PRIMARY KEY (user_id, role),
+    CONSTRAINT fk_user_roles_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_roles_role FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Administrators can manage everything'),
+    ('member', 'Regular users');
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:read', 'List and view users'),
+    ('users:write', 'Create and update users'),
+    ('users:delete', 'Delete users');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'users:read'),
+    ('admin', 'users:write'),
+    ('admin', 'users:delete');
+
+-- Users who signed up before RBAC was added: the oldest becomes the admin
+-- and the others members
+INSERT INTO user_roles (user_id, role)
+SELECT id, CASE WHEN id = (SELECT id FROM users ORDER BY created_at, id LIMIT 1) THEN 'admin' ELSE 'member' END
+FROM users;
+
+-- +goose Down
+DROP TABLE user_roles;
+DROP TABLE role_permissions;
+DROP TABLE permissions;
+DROP TABLE roles;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE jobs (
+    id CHAR(36) PRIMARY KEY,
+    kind VARCHAR(100) NOT NULL,
+    payload JSON NOT NULL,
+    unique_key VARCHAR(255) UNIQUE,
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL,
+    run_at DATETIME(6) NOT NULL,
+    locked_at DATETIME(6) NULL,
+    last_error TEXT NULL,
+    failed_at DATETIME(6) NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_jobs_run_at (run_at)
+);
+
+-- +goose Down
+DROP TABLE jobs;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE jobs (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    unique_key TEXT UNIQUE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    run_at DATETIME NOT NULL,
+    locked_at DATETIME,
+    last_error TEXT,
+    failed_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_jobs_run_at ON jobs(run_at) WHERE failed_at IS NULL;
+
+-- +goose Down
+DROP TABLE jobs;
@@ -0,0 +1,310 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cumulusware/gossamer/internal/config"
+	"github.com/cumulusware/gossamer/internal/manifest"
+	"github.com/cumulusware/gossamer/internal/merge"
+	"github.com/cumulusware/gossamer/internal/version"
+)
+
+// UpgradeAction describes what an upgrade does to a single file.
+type UpgradeAction string
+
+const (
+	ActionUnchanged UpgradeAction = "unchanged" // Project file already matches the templates
+	ActionKept      UpgradeAction = "kept"      // Template unchanged; local edits kept
+	ActionCreated   UpgradeAction = "created"   // New file added by the templates
+	ActionUpdated   UpgradeAction = "updated"   // Unmodified file replaced by the new version
+	ActionMerged    UpgradeAction = "merged"    // Local edits and template changes merged cleanly
+	ActionConflict  UpgradeAction = "conflict"  // Merged with conflict markers left in the file
+	ActionRejected  UpgradeAction = "rejected"  // No common ancestor; new version written to a .rej file
+	ActionSkipped   UpgradeAction = "skipped"   // Generated file deleted locally; left deleted
+)
+
+// UpgradeResult is the outcome of upgrading a single file.
+type UpgradeResult struct {
+	Path        string
+	Action      UpgradeAction
+	Conflicts   int
+	FromOverlay bool // New version rendered from an overlay directory
+}
+
+// Upgrader re-applies the embedded templates to an existing project using a
+// three-way merge between the content originally generated, the project's
+// current files, and the content the templates generate now.
+type Upgrader struct {
+	projectDir  string
+	config      *config.ProjectConfig
+	previous    *config.ProjectConfig // Set by AddFeature to limit the upgrade
+	manifest    *manifest.Manifest
+	files       []ProjectFile
+	templatesFS fs.FS
+}
+
+// NewUpgrader loads the manifest of the project at projectDir. The project
+// is upgraded using the configuration recorded in its manifest and the
+// blueprint it was generated from, which must still be installed.
+func NewUpgrader(projectDir string) (*Upgrader, error) {
+	if !manifest.Exists(projectDir) {
+		return nil, fmt.Errorf(
+			"%s not found in %s; only projects generated by gossamer can be upgraded",
+			manifest.FileName,
+			projectDir,
+		)
+	}
+
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	cfg := m.Config
+
+	b, err := FindBlueprint(cfg.Blueprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Upgrader{
+		projectDir:  projectDir,
+		config:      &cfg,
+		manifest:    m,
+		files:       b.Files,
+		templatesFS: b.TemplatesFS(),
+	}, nil
+}
+
+// TemplatesFS returns the templates the project is upgraded to.
+func (u *Upgrader) TemplatesFS() fs.FS {
+	return u.templatesFS
+}
+
+// SetTemplatesFS replaces the templates the project is upgraded to, e.g.,
+// with an OverlayFS.
+func (u *Upgrader) SetTemplatesFS(templatesFS fs.FS) {
+	u.templatesFS = templatesFS
+}
+
+// Config returns the project configuration used to render the templates.
+// Changes made to it are recorded in the manifest when the upgrade is
+// applied.
+func (u *Upgrader) Config() *config.ProjectConfig {
+	return u.config
+}
+
+// Manifest returns the project manifest.
+func (u *Upgrader) Manifest() *manifest.Manifest {
+	return u.manifest
+}
+
+// Plan reports what Apply would do without changing any files.
+func (u *Upgrader) Plan() ([]UpgradeResult, error) {
+	return u.run(false)
+}
+
+// Apply upgrades the project files and records the new state in the
+// manifest.
+func (u *Upgrader) Apply() ([]UpgradeResult, error) {
+	return u.run(true)
+}
+
+func (u *Upgrader) run(write bool) ([]UpgradeResult, error) {
+	var results []UpgradeResult
+	var addedVersion int64 // of the last migration created
+	labels := merge.Labels{
+		Ours:   "current",
+		Theirs: "gossamer " + version.Version,
+	}
+
+	for _, file := range u.files {
+		if !evalConditional(file.GetConditional(), u.config) {
+			continue
+		}
+
+		path := file.GetDestinationPath()
+		projectPath := path
+		isMigration := filepath.Dir(path) == filepath.FromSlash(migrationsDir)
+		if isMigration {
+			var err error
+			if projectPath, err = u.migrationFile(path); err != nil {
+				return nil, err
+			}
+		}
+		fullPath := filepath.Join(u.projectDir, projectPath)
+		if buildOutputs[path] {
+			if _, err := os.Stat(fullPath); err == nil {
+				continue
+			}
+		}
+
+		content, err := renderFile(u.templatesFS, file, u.config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate content for %s: %w", path, err)
+		}
+		theirs := []byte(content)
+
+		if u.previous != nil && evalConditional(file.GetConditional(), u.previous) {
+			before, err := renderFile(u.templatesFS, file, u.previous)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate content for %s: %w", path, err)
+			}
+			if before == content {
+				continue
+			}
+		}
+
+		base, hasBase, err := manifest.LoadPristine(u.projectDir, path)
+		if err != nil {
+			return nil, err
+		}
+
+		ours, err := os.ReadFile(fullPath)
+		exists := err == nil
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		result := UpgradeResult{Path: projectPath, FromOverlay: fromOverlay(u.templatesFS, file.GetSourcePath())}
+		var output []byte
+		outputPath := fullPath
+
+		_, generatedBefore := u.manifest.Files[filepath.ToSlash(path)]
+		templateUnchanged := (hasBase && bytes.Equal(base, theirs)) ||
+			(!hasBase && generatedBefore && !u.manifest.Modified(path, theirs))
+
+		switch {
+		case !exists && generatedBefore:
+			result.Action = ActionSkipped
+		case !exists:
+			result.Action = ActionCreated
+			output = theirs
+			if isMigration {
+				name, version, err := u.migrationName(path, addedVersion)
+				if err != nil {
+					return nil, err
+				}
+				result.Path = filepath.Join(filepath.Dir(path), name)
+				outputPath = filepath.Join(u.projectDir, result.Path)
+				addedVersion = version
+			}
+		case bytes.Equal(ours, theirs):
+			result.Action = ActionUnchanged
+		case templateUnchanged:
+			result.Action = ActionKept
+		case generatedBefore && !u.manifest.Modified(path, ours):
+			result.Action = ActionUpdated
+			output = theirs
+		case hasBase && utf8.Valid(base) && utf8.Valid(ours) && utf8.Valid(theirs):
+			merged := merge.ThreeWay(string(base), string(ours), string(theirs), labels)
+			result.Conflicts = merged.Conflicts
+			result.Action = ActionMerged
+			if merged.Conflicts > 0 {
+				result.Action = ActionConflict
+			}
+			output = []byte(merged.Text)
+		default:
+			result.Action = ActionRejected
+			output = theirs
+			outputPath = fullPath + ".rej"
+		}
+
+		results = append(results, result)
+		if !write {
+			continue
+		}
+
+		if output != nil {
+			if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+				return nil, fmt.Errorf("failed to create directory %s: %w", filepath.Dir(outputPath), err)
+			}
+			if err := os.WriteFile(outputPath, output, file.GetPermissions()); err != nil {
+				return nil, fmt.Errorf("failed to write file %s: %w", outputPath, err)
+			}
+		}
+
+		// The new template output becomes the common ancestor of the next upgrade
+		if result.Action != ActionSkipped && !buildOutputs[path] {
+			u.manifest.AddFile(path, theirs)
+			if err := manifest.SavePristine(u.projectDir, path, theirs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if write {
+		u.manifest.GossamerVersion = version.Version
+		u.manifest.Config = *u.config
+		u.manifest.Templates = overlayDir(u.templatesFS)
+		u.manifest.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+		if err := u.manifest.Save(u.projectDir); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// migrationFile returns the path of the project's migration generated from
+// the template migration at path, which may have been given another
+// version, see migrationName, or path if there is none.
+func (u *Upgrader) migrationFile(path string) (string, error) {
+	m := migrationVersionRegexp.FindStringSubmatch(filepath.Base(path))
+	if m == nil {
+		return path, nil
+	}
+	if _, err := os.Stat(filepath.Join(u.projectDir, path)); err == nil {
+		return path, nil
+	}
+
+	entries, err := os.ReadDir(filepath.Join(u.projectDir, filepath.Dir(path)))
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read migrations: %w", err)
+	}
+	for _, entry := range entries {
+		if other := migrationVersionRegexp.FindStringSubmatch(entry.Name()); other != nil && other[2] == m[2] {
+			return filepath.Join(filepath.Dir(path), entry.Name()), nil
+		}
+	}
+	return path, nil
+}
+
+// migrationName returns the file name and version the upgrade creates the
+// migration at path with, which is new to the project. The database may
+// have applied every migration of the project already, and goose refuses to
+// apply one older than those, so a migration numbered below them, e.g., of a
+// feature added after resources were generated, is given a version
+// following them and added, the version of the last migration the upgrade
+// created. The manifest keeps tracking it by its path in the templates.
+func (u *Upgrader) migrationName(path string, added int64) (string, int64, error) {
+	name := filepath.Base(path)
+	m := migrationVersionRegexp.FindStringSubmatch(name)
+	if m == nil {
+		return name, added, nil
+	}
+
+	highest, err := highestMigrationVersion(filepath.Join(u.projectDir, filepath.Dir(path)))
+	if err != nil {
+		return "", 0, err
+	}
+	highest = max(highest, added)
+	if version, err := strconv.ParseInt(m[1], 10, 64); err == nil && version > highest {
+		return name, version, nil
+	}
+
+	version := migrationVersionAfter(highest, time.Now())
+	n, _ := strconv.ParseInt(version, 10, 64)
+	return version + "_" + m[2] + ".sql", n, nil
+}
@@ -0,0 +1,414 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cumulusware/gossamer/internal/config"
+	"github.com/cumulusware/gossamer/internal/manifest"
+	"github.com/cumulusware/gossamer/internal/version"
+)
+
+// generateTestProject generates a project into a temporary directory and
+// returns its path.
+func generateTestProject(t *testing.T, cfg *config.ProjectConfig) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	if err := New(cfg).Generate(); err != nil {
+		t.Fatalf("Failed to generate project: %v", err)
+	}
+
+	return filepath.Join(tempDir, cfg.Name)
+}
+
+func TestUpgrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping file creation test in short mode")
+	}
+
+	cfg := &config.ProjectConfig{
+		Name:         "test-upgrade",
+		ModulePath:   "github.com/test/test-upgrade",
+		Description:  "Test upgrade",
+		DatabaseType: "postgresql",
+	}
+	projectDir := generateTestProject(t, cfg)
+
+	read := func(path string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(projectDir, path))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		return string(content)
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(projectDir, path), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	// replaceFirstLine swaps the first line of content, simulating a line
+	// that an older version of the templates generated differently.
+	replaceFirstLine := func(content, line string) string {
+		_, rest, _ := strings.Cut(content, "\n")
+		return line + "\n" + rest
+	}
+
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+	if _, ok := m.Files["static/css/app.css"]; ok {
+		t.Error("Manifest records static/css/app.css, which `just css` overwrites")
+	}
+	setGenerated := func(path, content string) {
+		t.Helper()
+		m.AddFile(path, []byte(content))
+		if err := manifest.SavePristine(projectDir, path, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Unmodified file generated by an older version
+	readme := read("README.md")
+	oldReadme := replaceFirstLine(readme, "# Old title")
+	setGenerated("README.md", oldReadme)
+	write("README.md", oldReadme)
+
+	// Local edits and template changes in different places
+	justfile := read("Justfile")
+	oldJustfile := replaceFirstLine(justfile, "# old header")
+	setGenerated("Justfile", oldJustfile)
+	write("Justfile", oldJustfile+"\n# my recipe\n")
+
+	// Local edits and template changes on the same line
+	gitignore := read(".gitignore")
+	setGenerated(".gitignore", replaceFirstLine(gitignore, "# old comment"))
+	write(".gitignore", replaceFirstLine(gitignore, "# my comment"))
+
+	// Local edits to a file the templates did not change
+	app := read("internal/app/app.go")
+	write("internal/app/app.go", app+"\n// my change\n")
+
+	// Modified file without a pristine copy
+	envTemplate := read("config/env.template")
+	m.AddFile("config/env.template", []byte("old"))
+	if err := os.Remove(filepath.Join(projectDir, manifest.PristineDir, "config/env.template")); err != nil {
+		t.Fatal(err)
+	}
+	write("config/env.template", "MINE=1\n")
+
+	// CSS built by `just css`
+	write("static/css/app.css", "/* built */\n")
+
+	// Generated file deleted by the user
+	if err := os.Remove(filepath.Join(projectDir, "cmd/server/main.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	// File added by the new templates
+	delete(m.Files, "static/robots.txt")
+	if err := os.Remove(filepath.Join(projectDir, "static/robots.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	m.GossamerVersion = "v0.0.0"
+	if err := m.Save(projectDir); err != nil {
+		t.Fatal(err)
+	}
+
+	upgrader, err := NewUpgrader(projectDir)
+	if err != nil {
+		t.Fatalf("NewUpgrader() error = %v", err)
+	}
+	planned, err := upgrader.Plan()
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if got := read("README.md"); got != oldReadme {
+		t.Error("Plan() changed README.md")
+	}
+
+	results, err := upgrader.Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(planned) != len(results) {
+		t.Errorf("Plan() returned %d results, Apply() returned %d", len(planned), len(results))
+	}
+
+	actions := map[string]UpgradeAction{}
+	for _, r := range results {
+		actions[r.Path] = r.Action
+	}
+	wantActions := map[string]UpgradeAction{
+		"README.md":           ActionUpdated,
+		"Justfile":            ActionMerged,
+		".gitignore":          ActionConflict,
+		"internal/app/app.go": ActionKept,
+		"config/env.template": ActionRejected,
+		"cmd/server/main.go":  ActionSkipped,
+		"static/robots.txt":   ActionCreated,
+		"go.mod":              ActionUnchanged,
+	}
+	for path, want := range wantActions {
+		if actions[path] != want {
+			t.Errorf("Action for %s = %q, want %q", path, actions[path], want)
+		}
+	}
+
+	if got := read("README.md"); got != readme {
+		t.Error("README.md was not replaced with the new version")
+	}
+	if got, want := read("Justfile"), justfile+"\n# my recipe\n"; got != want {
+		t.Errorf("Justfile merge =\n%s\nwant\n%s", got, want)
+	}
+	if got := read(".gitignore"); !strings.Contains(got, "<<<<<<< current\n# my comment\n=======\n") {
+		t.Errorf(".gitignore does not contain the expected conflict:\n%s", got)
+	}
+	if got := read("internal/app/app.go"); got != app+"\n// my change\n" {
+		t.Error("Local edits to internal/app/app.go were not kept")
+	}
+	if got := read("config/env.template"); got != "MINE=1\n" {
+		t.Error("config/env.template was overwritten")
+	}
+	if got := read("config/env.template.rej"); got != envTemplate {
+		t.Error("config/env.template.rej does not contain the new version")
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "cmd/server/main.go")); !os.IsNotExist(err) {
+		t.Error("Deleted file cmd/server/main.go was recreated")
+	}
+	read("static/robots.txt")
+	if _, ok := actions["static/css/app.css"]; ok {
+		t.Error("Upgrade changed static/css/app.css, which `just css` builds")
+	}
+	if got := read("static/css/app.css"); got != "/* built */\n" {
+		t.Error("static/css/app.css was overwritten")
+	}
+
+	// The new template output is the base of the next upgrade
+	m, err = manifest.Load(projectDir)
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+	if m.GossamerVersion != version.Version {
+		t.Errorf("Manifest version = %s, want %s", m.GossamerVersion, version.Version)
+	}
+	if m.UpdatedAt.IsZero() {
+		t.Error("Manifest update time not recorded")
+	}
+	if m.Modified("Justfile", []byte(justfile)) {
+		t.Error("Manifest hash for Justfile not updated")
+	}
+	pristine, ok, err := manifest.LoadPristine(projectDir, "Justfile")
+	if err != nil || !ok || string(pristine) != justfile {
+		t.Error("Pristine copy of Justfile not updated")
+	}
+}
+
+func TestNewUpgraderWithoutManifest(t *testing.T) {
+	if _, err := NewUpgrader(t.TempDir()); err == nil {
+		t.Error("NewUpgrader() expected error for a project without a manifest")
+	}
+}
+
+func TestAddFeature(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping file creation test in short mode")
+	}
+
+	cfg := &config.ProjectConfig{
+		Name:         "test-add",
+		ModulePath:   "github.com/test/test-add",
+		Description:  "Test add feature",
+		DatabaseType: "postgresql",
+	}
+	projectDir := generateTestProject(t, cfg)
+
+	// A local edit to a shared file that the API changes
+	routerPath := filepath.Join(projectDir, "internal/infrastructure/web/router.go")
+	router, err := os.ReadFile(routerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom := "\twebMux.HandleFunc(\"GET /about\", s.webHandlers.Home)\n"
+	edited := strings.Replace(string(router), "\t"+markerWebRoutes, custom+"\t"+markerWebRoutes, 1)
+	if err := os.WriteFile(routerPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgrader, err := NewUpgrader(projectDir)
+	if err != nil {
+		t.Fatalf("NewUpgrader() error = %v", err)
+	}
+	if err := upgrader.AddFeature("graphql"); err == nil {
+		t.Error("AddFeature() expected error for an unknown feature")
+	}
+	if err := upgrader.AddFeature("api"); err != nil {
+		t.Fatalf("AddFeature() error = %v", err)
+	}
+
+	results, err := upgrader.Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	actions := map[string]UpgradeAction{}
+	for _, r := range results {
+		// The API tokens migration follows the project's, see migrationName
+		if strings.HasSuffix(r.Path, "_create_api_tokens_table.sql") {
+			r.Path = "internal/infrastructure/database/migrations/<version>_create_api_tokens_table.sql"
+		}
+		actions[r.Path] = r.Action
+	}
+	wantActions := map[string]UpgradeAction{
+		"internal/adapters/handlers/api/handlers.go":                                        ActionCreated,
+		"internal/adapters/handlers/api/user_handler.go":                                    ActionCreated,
+		"internal/adapters/handlers/web/token_handler.go":                                   ActionCreated,
+		"internal/adapters/repository/api_token_postgres.go":                                ActionCreated,
+		"internal/infrastructure/database/migrations/<version>_create_api_tokens_table.sql": ActionCreated,
+		"internal/domain/auth/token.go":                                                     ActionCreated,
+		"internal/domain/auth/token_service.go":                                             ActionCreated,
+		"internal/domain/auth/token_service_test.go":                                        ActionCreated,
+		"internal/infrastructure/web/middleware/api_auth.go":                                ActionCreated,
+		"internal/infrastructure/web/middleware/cors.go":                                    ActionCreated,
+		"internal/infrastructure/web/middleware/cors_test.go":                               ActionCreated,
+		"internal/infrastructure/web/templates/tokens.gohtml":                               ActionCreated,
+		"internal/adapters/handlers/web/handlers.go":                                        ActionUpdated,
+		"internal/app/app.go":                                                               ActionUpdated,
+		"internal/infrastructure/config/config.go":                                          ActionUpdated,
+		"config/env.template":                                                               ActionUpdated,
+		"config/env.dev":                                                                    ActionUpdated,
+		"internal/infrastructure/web/server.go":                                             ActionUpdated,
+		"internal/infrastructure/web/templates/dashboard.gohtml":                            ActionUpdated,
+		"internal/infrastructure/web/router.go":                                             ActionMerged,
+	}
+	for path, want := range wantActions {
+		if actions[path] != want {
+			t.Errorf("Action for %s = %q, want %q", path, actions[path], want)
+		}
+	}
+	if len(results) != len(wantActions) {
+		t.Errorf("Apply() touched %d files, want only the %d affected by the API", len(results), len(wantActions))
+	}
+
+	merged, err := os.ReadFile(routerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{custom, "apiMux := http.NewServeMux()", markerAPIRoutes} {
+		if !strings.Contains(string(merged), want) {
+			t.Errorf("router.go does not contain %q after adding the API", want)
+		}
+	}
+
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+	if !m.Config.IncludeAPI {
+		t.Error("Manifest does not record the added feature")
+	}
+
+	upgrader, err = NewUpgrader(projectDir)
+	if err != nil {
+		t.Fatalf("NewUpgrader() error = %v", err)
+	}
+	if err := upgrader.AddFeature("api"); err == nil {
+		t.Error("AddFeature() expected error for a feature already enabled")
+	}
+}
+
+func TestAddFeatureAfterResourceMigrations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping file creation test in short mode")
+	}
+
+	cfg := &config.ProjectConfig{
+		Name:         "test-migrations",
+		ModulePath:   "github.com/test/test-migrations",
+		DatabaseType: "sqlite",
+	}
+	projectDir := generateTestProject(t, cfg)
+
+	// A resource numbered before migrations were versioned by time, taking
+	// the number of the RBAC tables, and one generated since
+	dir := filepath.Join(projectDir, migrationsDir)
+	for _, name := range []string{"004_create_products_table.sql", "20250101000000_create_orders_table.sql"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("-- +goose Up\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	upgrader, err := NewUpgrader(projectDir)
+	if err != nil {
+		t.Fatalf("NewUpgrader() error = %v", err)
+	}
+	if err := upgrader.AddFeature("rbac"); err != nil {
+		t.Fatalf("AddFeature() error = %v", err)
+	}
+	if _, err := upgrader.Apply(); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	// The RBAC migration follows the resources, with a version of its own
+	migrations := func() map[string]string {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions := map[string]string{}
+		for _, entry := range entries {
+			version, name, _ := strings.Cut(entry.Name(), "_")
+			if other, ok := versions[version]; ok {
+				t.Errorf("Migrations %s and %s share version %s", other, name, version)
+			}
+			versions[version] = name
+		}
+		return versions
+	}
+	before := migrations()
+	var rbac []string
+	for version, name := range before {
+		if name == "create_rbac_tables.sql" {
+			rbac = append(rbac, version)
+		}
+	}
+	if len(rbac) != 1 || rbac[0] <= "20250101000000" {
+		t.Fatalf("Expected one RBAC migration following the resources, got versions %v", rbac)
+	}
+
+	// Upgrading again finds the migration under its new version
+	upgrader, err = NewUpgrader(projectDir)
+	if err != nil {
+		t.Fatalf("NewUpgrader() error = %v", err)
+	}
+	results, err := upgrader.Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	for _, r := range results {
+		if strings.HasSuffix(r.Path, "_create_rbac_tables.sql") && r.Action != ActionUnchanged {
+			t.Errorf("Action for %s = %q, want %q", r.Path, r.Action, ActionUnchanged)
+		}
+	}
+	if after := migrations(); len(after) != len(before) {
+		t.Errorf("Upgrading again changed the migrations from %v to %v", before, after)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+// Package manifest records how a project was generated, so that later
+// commands know which configuration, gossamer version, and file contents
+// the project started from.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cumulusware/gossamer/internal/config"
+)
+
+// FileName is the name of the manifest file in the project root.
+const FileName = ".gossamer.yaml"
+
+// PristineDir holds an unmodified copy of every generated file, used as the
+// common ancestor when merging newer templates into the project.
+const PristineDir = ".gossamer/pristine"
+
+// Manifest is the record of a generated project stored in FileName.
+type Manifest struct {
+	GossamerVersion string               `yaml:"gossamer_version"`
+	GeneratedAt     time.Time            `yaml:"generated_at"`
+	UpdatedAt       time.Time            `yaml:"updated_at,omitempty"`
+	Config          config.ProjectConfig `yaml:"config"`
+	Templates       string               `yaml:"templates,omitempty"` // Templates overlay directory, if any
+	Resources       []string             `yaml:"resources,omitempty"`
+	Files           map[string]string    `yaml:"files"` // Project relative path to content hash
+}
+
+// New creates an empty manifest for a project generated from cfg by the
+// given gossamer version.
+func New(cfg config.ProjectConfig, gossamerVersion string) *Manifest {
+	return &Manifest{
+		GossamerVersion: gossamerVersion,
+		GeneratedAt:     time.Now().UTC().Truncate(time.Second),
+		Config:          cfg,
+		Files:           map[string]string{},
+	}
+}
+
+// Hash returns the content hash recorded for a file.
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// AddFile records the hash of a generated file. The path is stored with
+// forward slashes regardless of the operating system.
+func (m *Manifest) AddFile(path string, content []byte) {
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	m.Files[filepath.ToSlash(path)] = Hash(content)
+}
+
+// AddResource records a resource generated with `gossamer generate`.
+func (m *Manifest) AddResource(name string) {
+	for _, r := range m.Resources {
+		if r == name {
+			return
+		}
+	}
+	m.Resources = append(m.Resources, name)
+}
+
+// Modified reports whether the file at path differs from the content that
+// gossamer generated. Files not recorded in the manifest are reported as
+// modified.
+func (m *Manifest) Modified(path string, content []byte) bool {
+	hash, ok := m.Files[filepath.ToSlash(path)]
+	return !ok || hash != Hash(content)
+}
+
+// Exists reports whether projectDir contains a manifest.
+func Exists(projectDir string) bool {
+	_, err := os.Stat(filepath.Join(projectDir, FileName))
+	return err == nil
+}
+
+// Load reads the manifest from the root of projectDir.
+func Load(projectDir string) (*Manifest, error) {
+	path := filepath.Join(projectDir, FileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse project manifest %s: %w", path, err)
+	}
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+
+	return &m, nil
+}
+
+// Save writes the manifest to the root of projectDir.
+func (m *Manifest) Save(projectDir string) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to encode project manifest: %w", err)
+	}
+
+	header := []byte("# Generated by gossamer. Do not edit; used by gossamer to upgrade the project.\n")
+	path := filepath.Join(projectDir, FileName)
+	if err := os.WriteFile(path, append(header, data...), 0644); err != nil {
+		return fmt.Errorf("failed to write project manifest: %w", err)
+	}
+
+	return nil
+}
+
+// SavePristine stores the generated content of the file at path.
+func SavePristine(projectDir, path string, content []byte) error {
+	fullPath := filepath.Join(projectDir, PristineDir, path)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(fullPath), err)
+	}
+	if err := os.WriteFile(fullPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write pristine copy of %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadPristine returns the content gossamer last generated for the file at
+// path. The boolean is false if no pristine copy exists, as is the case for
+// projects generated before pristine copies were recorded.
+func LoadPristine(projectDir, path string) ([]byte, bool, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, PristineDir, path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read pristine copy of %s: %w", path, err)
+	}
+	return content, true, nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package manifest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cumulusware/gossamer/internal/config"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.ProjectConfig{
+		Name:         "my-app",
+		ModulePath:   "github.com/acme/my-app",
+		IncludeAPI:   true,
+		DatabaseType: "postgresql",
+		Blueprint:    "admin",
+		Options:      map[string]any{"company": "ACME", "audit": true},
+	}
+
+	m := New(cfg, "v1.2.3")
+	m.AddFile("go.mod", []byte("module github.com/acme/my-app\n"))
+	m.AddResource("Product")
+	m.Templates = "/home/acme/templates"
+
+	if Exists(dir) {
+		t.Fatal("Exists() reported a manifest before one was saved")
+	}
+	if err := m.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if !Exists(dir) {
+		t.Fatal("Exists() did not find the saved manifest")
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if loaded.GossamerVersion != "v1.2.3" {
+		t.Errorf("Expected version 'v1.2.3', got '%s'", loaded.GossamerVersion)
+	}
+	if !reflect.DeepEqual(loaded.Config, cfg) {
+		t.Errorf("Expected config %+v, got %+v", cfg, loaded.Config)
+	}
+	if !loaded.GeneratedAt.Equal(m.GeneratedAt) {
+		t.Errorf("Expected GeneratedAt %v, got %v", m.GeneratedAt, loaded.GeneratedAt)
+	}
+	if loaded.Templates != m.Templates {
+		t.Errorf("Expected templates %q, got %q", m.Templates, loaded.Templates)
+	}
+	if len(loaded.Resources) != 1 || loaded.Resources[0] != "Product" {
+		t.Errorf("Expected resources [Product], got %v", loaded.Resources)
+	}
+	if hash := loaded.Files["go.mod"]; !strings.HasPrefix(hash, "sha256:") {
+		t.Errorf("Expected sha256 hash for go.mod, got '%s'", hash)
+	}
+}
+
+func TestModified(t *testing.T) {
+	m := New(config.NewProjectConfig(), "v0.0.1")
+	m.AddFile("README.md", []byte("# my-app\n"))
+
+	if m.Modified("README.md", []byte("# my-app\n")) {
+		t.Error("Expected unchanged file to be reported as unmodified")
+	}
+	if !m.Modified("README.md", []byte("# my-app\n\nEdited.\n")) {
+		t.Error("Expected changed file to be reported as modified")
+	}
+	if !m.Modified("unknown.txt", nil) {
+		t.Error("Expected file missing from the manifest to be reported as modified")
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	if _, err := Load(t.TempDir()); err == nil {
+		t.Error("Expected error loading a manifest that does not exist")
+	}
+}
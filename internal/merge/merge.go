@@ -0,0 +1,241 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+// Package merge provides a line based three-way merge of text files.
+package merge
+
+import (
+	"sort"
+	"strings"
+)
+
+// Labels are the names written after the conflict markers.
+type Labels struct {
+	Ours   string
+	Theirs string
+}
+
+// Result is the outcome of a three-way merge.
+type Result struct {
+	Text      string
+	Conflicts int
+}
+
+// hunk is a change between base and another version: base[oStart:oEnd] was
+// replaced by other[sStart:sEnd].
+type hunk struct {
+	oStart, oEnd int
+	sStart, sEnd int
+	side         int
+}
+
+const (
+	sideOurs = iota
+	sideTheirs
+)
+
+// ThreeWay merges the changes made from base to ours and from base to
+// theirs. Changes touching the same or adjacent lines of base are written
+// as a conflict using Git style markers, unless both sides made the same
+// change.
+func ThreeWay(base, ours, theirs string, labels Labels) Result {
+	o := splitLines(base)
+	sides := [2][]string{splitLines(ours), splitLines(theirs)}
+
+	var hunks []hunk
+	for side, lines := range sides {
+		for _, h := range diff(o, lines) {
+			h.side = side
+			hunks = append(hunks, h)
+		}
+	}
+	sort.SliceStable(hunks, func(i, j int) bool {
+		if hunks[i].oStart != hunks[j].oStart {
+			return hunks[i].oStart < hunks[j].oStart
+		}
+		return hunks[i].side < hunks[j].side
+	})
+
+	var out strings.Builder
+	var conflicts int
+	pos := 0
+
+	for i := 0; i < len(hunks); {
+		// Collect every hunk overlapping or touching the current region
+		start, end := hunks[i].oStart, hunks[i].oEnd
+		j := i + 1
+		for j < len(hunks) && hunks[j].oStart <= end {
+			if hunks[j].oEnd > end {
+				end = hunks[j].oEnd
+			}
+			j++
+		}
+		region := hunks[i:j]
+		i = j
+
+		writeLines(&out, o[pos:start])
+		pos = end
+
+		var perSide [2][]hunk
+		for _, h := range region {
+			perSide[h.side] = append(perSide[h.side], h)
+		}
+
+		oursText := sideLines(sides[sideOurs], o, perSide[sideOurs], start, end)
+		theirsText := sideLines(sides[sideTheirs], o, perSide[sideTheirs], start, end)
+
+		switch {
+		case len(perSide[sideTheirs]) == 0:
+			writeLines(&out, oursText)
+		case len(perSide[sideOurs]) == 0:
+			writeLines(&out, theirsText)
+		case equalLines(oursText, theirsText):
+			writeLines(&out, oursText)
+		default:
+			conflicts++
+			out.WriteString("<<<<<<< " + labels.Ours + "\n")
+			writeConflictLines(&out, oursText)
+			out.WriteString("=======\n")
+			writeConflictLines(&out, theirsText)
+			out.WriteString(">>>>>>> " + labels.Theirs + "\n")
+		}
+	}
+	writeLines(&out, o[pos:])
+
+	return Result{Text: out.String(), Conflicts: conflicts}
+}
+
+// sideLines returns the lines of one side corresponding to base[start:end],
+// given the hunks of that side falling within the region. Outside of its
+// hunks a side is identical to base, which fixes the offsets at both ends.
+func sideLines(lines, base []string, hunks []hunk, start, end int) []string {
+	if len(hunks) == 0 {
+		return base[start:end]
+	}
+	first, last := hunks[0], hunks[len(hunks)-1]
+	return lines[first.sStart-(first.oStart-start) : last.sEnd+(end-last.oEnd)]
+}
+
+// diff returns the hunks turning a into b, computed from the shortest edit
+// script found with Myers' algorithm.
+func diff(a, b []string) []hunk {
+	n, m := len(a), len(b)
+	maxD := n + m
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	var trace [][]int
+
+	found := false
+	for d := 0; d <= maxD && !found; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	// Walk back through the trace collecting the matched line pairs
+	type pair struct{ a, b int }
+	var matches []pair
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0 && (x > 0 || y > 0); d-- {
+		vd := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && vd[offset+k-1] < vd[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := vd[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			matches = append(matches, pair{x, y})
+		}
+		if d > 0 {
+			x, y = prevX, prevY
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		matches = append(matches, pair{x, y})
+	}
+
+	// Turn the gaps between matched lines into hunks
+	var hunks []hunk
+	ai, bi := 0, 0
+	for i := len(matches) - 1; i >= -1; i-- {
+		ma, mb := n, m
+		if i >= 0 {
+			ma, mb = matches[i].a, matches[i].b
+		}
+		if ma > ai || mb > bi {
+			hunks = append(hunks, hunk{oStart: ai, oEnd: ma, sStart: bi, sEnd: mb})
+		}
+		ai, bi = ma+1, mb+1
+	}
+
+	return hunks
+}
+
+// splitLines splits text into lines, keeping the line endings so that the
+// merged text reproduces them exactly.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func writeLines(b *strings.Builder, lines []string) {
+	for _, l := range lines {
+		b.WriteString(l)
+	}
+}
+
+// writeConflictLines writes lines inside a conflict block, making sure the
+// marker that follows starts on its own line.
+func writeConflictLines(b *strings.Builder, lines []string) {
+	writeLines(b, lines)
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		b.WriteString("\n")
+	}
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+package merge
+
+import (
+	"strings"
+	"testing"
+)
+
+var labels = Labels{Ours: "current", Theirs: "gossamer"}
+
+func lines(s ...string) string {
+	return strings.Join(s, "\n") + "\n"
+}
+
+func TestThreeWay(t *testing.T) {
+	base := lines("a", "b", "c", "d", "e", "f", "g")
+
+	tests := []struct {
+		name          string
+		ours          string
+		theirs        string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:   "no changes",
+			ours:   base,
+			theirs: base,
+			want:   base,
+		},
+		{
+			name:   "only ours changed",
+			ours:   lines("a", "B", "c", "d", "e", "f", "g"),
+			theirs: base,
+			want:   lines("a", "B", "c", "d", "e", "f", "g"),
+		},
+		{
+			name:   "only theirs changed",
+			ours:   base,
+			theirs: lines("a", "b", "c", "d", "e", "F", "g"),
+			want:   lines("a", "b", "c", "d", "e", "F", "g"),
+		},
+		{
+			name:   "non-overlapping changes",
+			ours:   lines("a", "B", "c", "d", "e", "f", "g"),
+			theirs: lines("a", "b", "c", "d", "e", "F", "g", "h"),
+			want:   lines("a", "B", "c", "d", "e", "F", "g", "h"),
+		},
+		{
+			name:   "insertions and deletions",
+			ours:   lines("a", "a2", "b", "c", "d", "e", "f", "g"),
+			theirs: lines("a", "b", "c", "e", "f", "g"),
+			want:   lines("a", "a2", "b", "c", "e", "f", "g"),
+		},
+		{
+			name:   "same change on both sides",
+			ours:   lines("a", "b", "C", "d", "e", "f", "g"),
+			theirs: lines("a", "b", "C", "d", "e", "f", "g"),
+			want:   lines("a", "b", "C", "d", "e", "f", "g"),
+		},
+		{
+			name:   "conflicting changes",
+			ours:   lines("a", "b", "ours", "d", "e", "f", "g"),
+			theirs: lines("a", "b", "theirs", "d", "e", "f", "g"),
+			want: lines("a", "b",
+				"<<<<<<< current", "ours", "=======", "theirs", ">>>>>>> gossamer",
+				"d", "e", "f", "g"),
+			wantConflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ThreeWay(base, tt.ours, tt.theirs, labels)
+			if got.Text != tt.want {
+				t.Errorf("ThreeWay() text =\n%s\nwant\n%s", got.Text, tt.want)
+			}
+			if got.Conflicts != tt.wantConflicts {
+				t.Errorf("ThreeWay() conflicts = %d, want %d", got.Conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestThreeWayMissingFinalNewline(t *testing.T) {
+	got := ThreeWay("a\nb", "a\nours", "a\ntheirs", labels)
+	want := "a\n<<<<<<< current\nours\n=======\ntheirs\n>>>>>>> gossamer\n"
+	if got.Text != want {
+		t.Errorf("ThreeWay() text = %q, want %q", got.Text, want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := splitLines(lines("a", "b", "c", "d"))
+	b := splitLines(lines("a", "x", "c", "d", "e"))
+
+	hunks := diff(a, b)
+	if len(hunks) != 2 {
+		t.Fatalf("Expected 2 hunks, got %d: %+v", len(hunks), hunks)
+	}
+	if h := hunks[0]; h.oStart != 1 || h.oEnd != 2 || h.sStart != 1 || h.sEnd != 2 {
+		t.Errorf("Unexpected first hunk %+v", h)
+	}
+	if h := hunks[1]; h.oStart != 4 || h.oEnd != 4 || h.sStart != 4 || h.sEnd != 5 {
+		t.Errorf("Unexpected second hunk %+v", h)
+	}
+}
@@ -3,8 +3,6 @@ package prompts
 
 import (
 	"fmt"
-	"log"
-	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/huh"
@@ -13,14 +11,18 @@ import (
 	"github.com/cumulusware/gossamer/internal/config"
 )
 
-func GetProjectConfig(projectName string) (*config.ProjectConfig, error) {
-	cfg := config.NewProjectConfig()
+// GetProjectConfig runs the interactive forms used to configure a new
+// project, including the options defined by its blueprint. Any values
+// already set in seed (e.g., from command line flags or a config file) are
+// used as the defaults shown in the forms.
+func GetProjectConfig(seed *config.ProjectConfig, options []config.Option) (*config.ProjectConfig, error) {
+	cfg := *seed
 
 	fmt.Println(color.CyanString("🔧 Let's configure your new Go web application!"))
 	fmt.Println()
 
 	// If project name not provided, prompt for it
-	if projectName == "" {
+	if cfg.Name == "" {
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewInput().
@@ -36,19 +38,15 @@ func GetProjectConfig(projectName string) (*config.ProjectConfig, error) {
 			return nil, err
 		}
 	} else {
-		cfg.Name = projectName
 		if err := validateProjectName(cfg.Name); err != nil {
 			return nil, err
 		}
 	}
 
-	cfg.EnvName = strings.ToUpper(cfg.Name)
-
 	// Module path
-	defaultModule := strings.ReplaceAll(cfg.Name, "-", "")
-	cfg.ModulePath = defaultModule
-
-	log.Printf("Module path before user input = %s", cfg.ModulePath)
+	if cfg.ModulePath == "" {
+		cfg.ModulePath = strings.ReplaceAll(cfg.Name, "-", "")
+	}
 
 	form := huh.NewForm(
 		huh.NewGroup(
@@ -61,7 +59,7 @@ func GetProjectConfig(projectName string) (*config.ProjectConfig, error) {
 			huh.NewInput().
 				Title("Brief description of your project (optional):").
 				Description("This will be used in README.md and comments").
-				Placeholder("A modern Go web application").
+				Placeholder(config.DefaultDescription).
 				Value(&cfg.Description),
 
 			huh.NewInput().
@@ -71,16 +69,12 @@ func GetProjectConfig(projectName string) (*config.ProjectConfig, error) {
 		),
 	)
 
-	log.Printf("Module path after user input = %s", cfg.ModulePath)
-
 	if err := form.Run(); err != nil {
 		return nil, err
 	}
 
-	// Set default description if empty
-	if cfg.Description == "" {
-		cfg.Description = "A modern Go web application"
-	}
+	// Set default description, env name, etc. if empty
+	cfg.ApplyDefaults()
 
 	fmt.Println(color.MagentaString("\n🎛️ Feature Selection:"))
 
@@ -101,11 +95,34 @@ func GetProjectConfig(projectName string) (*config.ProjectConfig, error) {
 				Affirmative("Yes").
 				Negative("No"),
 
+			huh.NewConfirm().
+				Title("Include role-based access control?").
+				Description("Adds admin and member roles with permissions checked by middleware and templates").
+				Value(&cfg.IncludeRBAC).
+				Affirmative("Yes").
+				Negative("No"),
+
+			huh.NewConfirm().
+				Title("Include background jobs?").
+				Description("Runs jobs queued in the database, with retries and periodic jobs, in a worker pool").
+				Value(&cfg.IncludeJobs).
+				Affirmative("Yes").
+				Negative("No"),
+
+			huh.NewConfirm().
+				Title("Include OpenTelemetry tracing?").
+				Description("Traces requests and database queries, exported over OTLP or printed to stdout").
+				Value(&cfg.IncludeTracing).
+				Affirmative("Yes").
+				Negative("No"),
+
 			huh.NewSelect[string]().
 				Title("Choose database:").
-				Description("Currently only PostgreSQL is supported").
+				Description("MySQL also works with MariaDB; SQLite keeps everything in a single file, with no database server to run").
 				Options(
 					huh.NewOption("PostgreSQL", "postgresql"),
+					huh.NewOption("MySQL / MariaDB", "mysql"),
+					huh.NewOption("SQLite", "sqlite"),
 				).
 				Value(&cfg.DatabaseType),
 		),
@@ -115,12 +132,25 @@ func GetProjectConfig(projectName string) (*config.ProjectConfig, error) {
 		return nil, err
 	}
 
+	if len(options) > 0 {
+		fmt.Println(color.MagentaString("\n🧩 Blueprint Options:"))
+		if err := getOptions(&cfg, options); err != nil {
+			return nil, err
+		}
+	}
+
 	fmt.Println(color.GreenString("\n📋 Configuration Summary:"))
 	fmt.Printf("  Project: %s\n", cfg.Name)
 	fmt.Printf("  Module:  %s\n", cfg.ModulePath)
 	fmt.Printf("  HTMX:    %s\n", boolToYesNo(cfg.IncludeHTMX))
 	fmt.Printf("  API:     %s\n", boolToYesNo(cfg.IncludeAPI))
+	fmt.Printf("  RBAC:    %s\n", boolToYesNo(cfg.IncludeRBAC))
+	fmt.Printf("  Jobs:    %s\n", boolToYesNo(cfg.IncludeJobs))
+	fmt.Printf("  Tracing: %s\n", boolToYesNo(cfg.IncludeTracing))
 	fmt.Printf("  Database: %s\n", cfg.DatabaseType)
+	for _, o := range options {
+		fmt.Printf("  %s: %v\n", o.Name, cfg.Options[o.Name])
+	}
 
 	// Confirmation
 	confirmed := true
@@ -145,44 +175,76 @@ func GetProjectConfig(projectName string) (*config.ProjectConfig, error) {
 	return &cfg, nil
 }
 
-func validateProjectName(val string) error {
-	if val == "" {
-		return fmt.Errorf("project name cannot be empty")
+// getOptions runs the form asking for the values of the blueprint options,
+// using the values already in cfg as the defaults.
+func getOptions(cfg *config.ProjectConfig, options []config.Option) error {
+	// Copy the options so the map of the seed is left untouched
+	values := make(map[string]any, len(options))
+	for name, val := range cfg.Options {
+		values[name] = val
 	}
+	cfg.Options = values
+	cfg.ApplyOptionDefaults(options)
 
-	// Check for valid directory name
-	matched, _ := regexp.MatchString(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`, val)
-	if !matched {
-		return fmt.Errorf(
-			"project name must start with letter/number and contain only letters, numbers, hyphens, and underscores",
-		)
-	}
+	fields := make([]huh.Field, 0, len(options))
+	bools := make(map[string]*bool)
+	strs := make(map[string]*string)
 
-	// Check for reserved names
-	reserved := []string{"go", "test", "main", "src", "pkg", "cmd", "internal"}
-	for _, r := range reserved {
-		if strings.EqualFold(val, r) {
-			return fmt.Errorf("'%s' is a reserved name", val)
+	for _, o := range options {
+		title := o.Title
+		if title == "" {
+			title = o.Name
 		}
-	}
 
-	return nil
-}
-
-func validateModulePath(val string) error {
-	if val == "" {
-		return fmt.Errorf("module path cannot be empty")
+		switch o.Type {
+		case config.OptionBool:
+			val, _ := cfg.Options[o.Name].(bool)
+			bools[o.Name] = &val
+			fields = append(fields, huh.NewConfirm().
+				Title(title).
+				Description(o.Description).
+				Value(&val).
+				Affirmative("Yes").
+				Negative("No"))
+		case config.OptionChoice:
+			val, _ := cfg.Options[o.Name].(string)
+			strs[o.Name] = &val
+			fields = append(fields, huh.NewSelect[string]().
+				Title(title).
+				Description(o.Description).
+				Options(huh.NewOptions(o.Choices...)...).
+				Value(&val))
+		default:
+			val, _ := cfg.Options[o.Name].(string)
+			strs[o.Name] = &val
+			fields = append(fields, huh.NewInput().
+				Title(title).
+				Description(o.Description).
+				Value(&val))
+		}
 	}
 
-	// Basic validation for Go module path
-	matched, _ := regexp.MatchString(`^[a-zA-Z0-9][a-zA-Z0-9._/-]*[a-zA-Z0-9]$`, val)
-	if !matched {
-		return fmt.Errorf("invalid module path format")
+	if err := huh.NewForm(huh.NewGroup(fields...)).Run(); err != nil {
+		return err
 	}
 
+	for name, val := range bools {
+		cfg.Options[name] = *val
+	}
+	for name, val := range strs {
+		cfg.Options[name] = *val
+	}
 	return nil
 }
 
+func validateProjectName(val string) error {
+	return config.ValidateProjectName(val)
+}
+
+func validateModulePath(val string) error {
+	return config.ValidateModulePath(val)
+}
+
 func boolToYesNo(b bool) string {
 	if b {
 		return color.GreenString("Yes")
@@ -0,0 +1,11 @@
+// Copyright (c) 2024-2025 The gossamer developers. All rights reserved.
+// Project site: https://github.com/cumulusware/gossamer
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE file for the project.
+
+// Package version provides the version of gossamer.
+package version
+
+// Version is the gossamer version. It can be overridden at build time with
+// -ldflags "-X github.com/cumulusware/gossamer/internal/version.Version=...".
+var Version = "v0.0.1"